@@ -23,6 +23,7 @@ type server struct {
 	Host     string
 	Port     uint16
 	Debug    bool
+	Admins   string
 }
 
 type database struct {
@@ -43,11 +44,23 @@ type rules struct {
 	MaxJoinedGames             int32 `yaml:"maxJoinedGames"`
 }
 
+type engine struct {
+	Path           string
+	MoveTimeMillis int32 `yaml:"moveTimeMillis"`
+}
+
+type analysis struct {
+	Enabled bool
+	Level   string
+}
+
 type Config struct {
 	General  general
 	Server   server
 	Database database
 	Rules    rules
+	Engine   engine
+	Analysis analysis
 }
 
 const defaultConfigPath = "./config.yaml"
@@ -179,7 +179,8 @@ const docTemplate = `{
                             "GameStartEvent",
                             "GameEndEvent",
                             "GameWhitePlayerMoveEvent",
-                            "GameWhitePlayerMoveEvent"
+                            "GameWhitePlayerMoveEvent",
+                            "GameTakebackEvent"
                         ],
                         "type": "string",
                         "description": "Event type",
@@ -298,7 +299,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create new game",
+                "description": "Create new game, which the computer player of the chosen level joins immediately in vs computer mode.\nThe game can start from the custom position set either by FEN or by tiles and the player on turn.",
                 "consumes": [
                     "application/json"
                 ],
@@ -348,6 +349,69 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/games/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import finished games from PGN after replaying them through the rules engine, only for admins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Import games from PGN",
+                "parameters": [
+                    {
+                        "description": "Import games",
+                        "name": "game",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GameImport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GameListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/games/{id}": {
             "get": {
                 "security": [
@@ -400,24 +464,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/games/{id}/join": {
-            "post": {
+        "/v1/games/{id}/analysis": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Join existing game",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the analysis of the ended game with the score after each move in centipawns from the white\nplayer's point of view, the best move, the classification of inaccuracies, mistakes and blunders and\nthe accuracy of each player",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "games"
                 ],
-                "summary": "Join existing game",
+                "summary": "Get game analysis",
                 "parameters": [
                     {
                         "type": "integer",
@@ -425,21 +486,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Join game",
-                        "name": "game",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/model.GameJoin"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericResponse"
+                            "$ref": "#/definitions/model.GameAnalysis"
                         }
                     },
                     "400": {
@@ -463,14 +516,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/games/{id}/move": {
-            "post": {
+        "/v1/games/{id}/hints": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Make game move",
+                "description": "Enable or disable the move hints in the game (e.g. for rated games), only for admins",
                 "consumes": [
                     "application/json"
                 ],
@@ -480,7 +533,7 @@ const docTemplate = `{
                 "tags": [
                     "games"
                 ],
-                "summary": "Make game move",
+                "summary": "Enable or disable hints",
                 "parameters": [
                     {
                         "type": "integer",
@@ -490,12 +543,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Game move",
-                        "name": "game",
+                        "description": "Game hints",
+                        "name": "hints",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.GameMakeMove"
+                            "$ref": "#/definitions/model.GameHints"
                         }
                     }
                 ],
@@ -503,7 +556,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericResponse"
+                            "$ref": "#/definitions/model.Game"
                         }
                     },
                     "400": {
@@ -533,52 +586,104 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/games/{id}/moves": {
-            "get": {
+        "/v1/games/{id}/join": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Query and list game moves",
+                "description": "Join existing game",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "games"
                 ],
-                "summary": "Query and list game moves",
+                "summary": "Join existing game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Join game",
+                        "name": "game",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.GameJoin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/games/{id}/legal-moves": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Filter",
-                        "name": "filter",
-                        "in": "query"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all legal moves of the player on turn in the current position of the game in progress",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "List legal moves",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.GameListResponse"
+                            "$ref": "#/definitions/model.GameLegalMoveListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
@@ -596,14 +701,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/games/{id}/quit": {
+        "/v1/games/{id}/move": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Quit joined game",
+                "description": "Make game move",
                 "consumes": [
                     "application/json"
                 ],
@@ -613,7 +718,7 @@ const docTemplate = `{
                 "tags": [
                     "games"
                 ],
-                "summary": "Quit joined game",
+                "summary": "Make game move",
                 "parameters": [
                     {
                         "type": "integer",
@@ -621,6 +726,15 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Game move",
+                        "name": "game",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GameMakeMove"
+                        }
                     }
                 ],
                 "responses": {
@@ -657,21 +771,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/players": {
+        "/v1/games/{id}/moves": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Query and list players",
+                "description": "Query and list game moves",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
                 ],
-                "summary": "Query and list players",
+                "summary": "Query and list game moves",
                 "parameters": [
                     {
                         "type": "integer",
@@ -696,13 +810,25 @@ const docTemplate = `{
                         "description": "Filter",
                         "name": "filter",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Move format (san, uci)",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.PlayerListResponse"
+                            "$ref": "#/definitions/model.GameMoveListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
@@ -720,24 +846,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/players/delete": {
-            "delete": {
+        "/v1/games/{id}/pgn": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete player account",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export game in Portable Game Notation with Seven Tag Roster and moves in Standard Algebraic Notation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
+                ],
+                "summary": "Export game in PGN",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete player account",
                 "responses": {
                     "200": {
                         "description": "Ok",
@@ -745,6 +877,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.GenericResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -760,9 +898,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/players/register": {
+        "/v1/games/{id}/quit": {
             "post": {
-                "description": "Register new player",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Quit joined game",
                 "consumes": [
                     "application/json"
                 ],
@@ -770,19 +913,409 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
                 ],
-                "summary": "Register new player",
+                "summary": "Quit joined game",
                 "parameters": [
                     {
-                        "description": "Register player",
-                        "name": "player",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PlayerRequest"
-                        }
-                    }
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/games/{id}/takeback": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Request to take back the last move of the player, together with the reply of the opponent if it was\nalready played. The opponent must accept the request, while the computer player accepts it immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Request takeback",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.Game"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/games/{id}/takeback/respond": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accept or decline the takeback request of the opponent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Respond to takeback request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Takeback response",
+                        "name": "takeback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GameTakebackResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.Game"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/players": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Query and list players",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Query and list players",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlayerListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/players/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete player account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Delete player account",
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/players/register": {
+            "post": {
+                "description": "Register new player",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Register new player",
+                "parameters": [
+                    {
+                        "description": "Register player",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/players/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update player account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Update player account",
+                "parameters": [
+                    {
+                        "description": "Update player data",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/players/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find one player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Find one player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -797,6 +1330,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -806,14 +1345,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/players/update": {
-            "put": {
+        "/v1/positions/{fen}/games": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update player account",
+                "description": "Query and list games which have passed through the position in URL encoded FEN, where the positions\nare equal if they have the same figures, player on turn, castling rights and en passant tile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "positions"
+                ],
+                "summary": "List games by position",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Position in FEN",
+                        "name": "fen",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.GameListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/puzzles/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import puzzles in the CSV format of the Lichess puzzle database, where the puzzles which have already\nbeen imported are skipped, only for admins",
                 "consumes": [
                     "application/json"
                 ],
@@ -821,17 +1436,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "puzzles"
                 ],
-                "summary": "Update player account",
+                "summary": "Import puzzles from Lichess CSV",
                 "parameters": [
                     {
-                        "description": "Update player data",
-                        "name": "player",
+                        "description": "Import puzzles",
+                        "name": "puzzle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PlayerRequest"
+                            "$ref": "#/definitions/model.PuzzleImport"
                         }
                     }
                 ],
@@ -839,7 +1454,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.GenericResponse"
+                            "$ref": "#/definitions/model.PuzzleImportResult"
                         }
                     },
                     "400": {
@@ -854,6 +1469,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -863,35 +1484,142 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/players/{id}": {
+        "/v1/puzzles/next": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the random puzzle not yet solved or failed by the player with the rating closest to the puzzle\nrating of the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "puzzles"
+                ],
+                "summary": "Get next puzzle",
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.Puzzle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/puzzles/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Find one player",
+                "description": "Find one puzzle without its solution",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "puzzles"
+                ],
+                "summary": "Find one puzzle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Puzzle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.Puzzle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/puzzles/{id}/attempt": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check the moves of the player in SAN or UCI format played from the starting position of the puzzle,\nwhere each move is followed by the reply of the opponent from the solution. The puzzle is finished\nwhen it is solved or when the move differs from the solution, and only the first finished attempt of\nthe player updates the puzzle ratings of both the player and the puzzle.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "puzzles"
                 ],
-                "summary": "Find one player",
+                "summary": "Attempt to solve puzzle",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Player ID",
+                        "description": "Puzzle ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Puzzle attempt",
+                        "name": "attempt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PuzzleAttempt"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/model.Player"
+                            "$ref": "#/definitions/model.PuzzleAttemptResult"
                         }
                     },
                     "400": {
@@ -967,6 +1695,9 @@ const docTemplate = `{
         "model.Game": {
             "type": "object",
             "properties": {
+                "blackChecks": {
+                    "type": "integer"
+                },
                 "blackPlayerId": {
                     "type": "integer"
                 },
@@ -979,9 +1710,18 @@ const docTemplate = `{
                 "creatorId": {
                     "type": "integer"
                 },
+                "eco": {
+                    "type": "string"
+                },
                 "endedAt": {
                     "type": "string"
                 },
+                "fen": {
+                    "type": "string"
+                },
+                "hintsEnabled": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -994,18 +1734,45 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "opening": {
+                    "type": "string"
+                },
+                "pocket": {
+                    "type": "string"
+                },
                 "public": {
                     "type": "boolean"
                 },
+                "result": {
+                    "type": "string"
+                },
                 "startedAt": {
                     "type": "string"
                 },
+                "startingFen": {
+                    "type": "string"
+                },
+                "startingPositionId": {
+                    "type": "integer"
+                },
+                "takebackPlayerId": {
+                    "type": "integer"
+                },
+                "takebacksEnabled": {
+                    "type": "boolean"
+                },
                 "tiles": {
                     "type": "string"
                 },
                 "turnDurationSeconds": {
                     "type": "integer"
                 },
+                "variant": {
+                    "type": "string"
+                },
+                "whiteChecks": {
+                    "type": "integer"
+                },
                 "whitePlayerId": {
                     "type": "integer"
                 },
@@ -1017,9 +1784,44 @@ const docTemplate = `{
                 }
             }
         },
+        "model.GameAnalysis": {
+            "type": "object",
+            "properties": {
+                "blackAccuracy": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "integer"
+                },
+                "moves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GameMoveAnalysis"
+                    }
+                },
+                "whiteAccuracy": {
+                    "type": "number"
+                }
+            }
+        },
         "model.GameCreate": {
             "type": "object",
             "properties": {
+                "blackOnTurn": {
+                    "type": "boolean"
+                },
+                "computerLevel": {
+                    "type": "string"
+                },
+                "disableTakebacks": {
+                    "type": "boolean"
+                },
+                "fen": {
+                    "type": "string"
+                },
                 "isWhite": {
                     "type": "boolean"
                 },
@@ -1029,8 +1831,33 @@ const docTemplate = `{
                 "password": {
                     "type": "string"
                 },
+                "tiles": {
+                    "type": "string"
+                },
                 "turnDurationSeconds": {
                     "type": "integer"
+                },
+                "variant": {
+                    "type": "string"
+                },
+                "vsComputer": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.GameHints": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.GameImport": {
+            "type": "object",
+            "properties": {
+                "pgn": {
+                    "type": "string"
                 }
             }
         },
@@ -1042,6 +1869,37 @@ const docTemplate = `{
                 }
             }
         },
+        "model.GameLegalMove": {
+            "type": "object",
+            "properties": {
+                "move": {
+                    "type": "string"
+                },
+                "san": {
+                    "type": "string"
+                },
+                "uci": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GameLegalMoveListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GameLegalMove"
+                    }
+                },
+                "resultCount": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.GameListResponse": {
             "type": "object",
             "properties": {
@@ -1062,8 +1920,88 @@ const docTemplate = `{
         "model.GameMakeMove": {
             "type": "object",
             "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "move": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GameMove": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "move": {
+                    "type": "string"
+                },
+                "playerId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GameMoveAnalysis": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "type": "number"
+                },
+                "bestMove": {
+                    "type": "string"
+                },
+                "classification": {
+                    "type": "string"
+                },
+                "gameMoveId": {
+                    "type": "integer"
+                },
+                "loss": {
+                    "type": "integer"
+                },
+                "mateIn": {
+                    "type": "integer"
+                },
                 "move": {
                     "type": "string"
+                },
+                "san": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GameMoveListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GameMove"
+                    }
+                },
+                "resultCount": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GameTakebackResponse": {
+            "type": "object",
+            "properties": {
+                "accept": {
+                    "type": "boolean"
                 }
             }
         },
@@ -1093,6 +2031,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "isBot": {
+                    "type": "boolean"
+                },
                 "isPlaying": {
                     "type": "boolean"
                 },
@@ -1102,6 +2043,9 @@ const docTemplate = `{
                 "losses": {
                     "type": "integer"
                 },
+                "puzzleElo": {
+                    "type": "integer"
+                },
                 "rate": {
                     "type": "number"
                 },
@@ -1140,6 +2084,109 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.Puzzle": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fen": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isWhiteTurn": {
+                    "type": "boolean"
+                },
+                "lichessId": {
+                    "type": "string"
+                },
+                "plays": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "themes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tiles": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PuzzleAttempt": {
+            "type": "object",
+            "properties": {
+                "moves": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.PuzzleAttemptResult": {
+            "type": "object",
+            "properties": {
+                "correct": {
+                    "type": "boolean"
+                },
+                "fen": {
+                    "type": "string"
+                },
+                "playerRating": {
+                    "type": "integer"
+                },
+                "puzzleRating": {
+                    "type": "integer"
+                },
+                "ratingChange": {
+                    "type": "integer"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "replySan": {
+                    "type": "string"
+                },
+                "solution": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "solved": {
+                    "type": "boolean"
+                },
+                "tiles": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PuzzleImport": {
+            "type": "object",
+            "properties": {
+                "csv": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PuzzleImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                },
+                "parsed": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
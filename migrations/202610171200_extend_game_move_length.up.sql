@@ -0,0 +1,2 @@
+ALTER TABLE game_move
+    ALTER COLUMN "move" TYPE character varying(16);
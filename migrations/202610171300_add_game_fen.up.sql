@@ -0,0 +1,6 @@
+ALTER TABLE game
+    ADD COLUMN "fen" character varying NULL;
+
+UPDATE game
+SET "fen" = 'rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1'
+WHERE (SELECT COUNT(*) FROM game_move WHERE game_move."gameId" = game.id) = 0;
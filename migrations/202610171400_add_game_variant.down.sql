@@ -0,0 +1,4 @@
+ALTER TABLE game
+    DROP COLUMN "startingPositionId";
+ALTER TABLE game
+    DROP COLUMN "variant";
@@ -0,0 +1,4 @@
+ALTER TABLE game
+    ADD COLUMN "variant" character varying NOT NULL DEFAULT 'standard';
+ALTER TABLE game
+    ADD COLUMN "startingPositionId" integer NOT NULL DEFAULT 518;
@@ -0,0 +1,6 @@
+DELETE
+FROM player
+WHERE "isBot" IS TRUE;
+
+ALTER TABLE player
+    DROP COLUMN "isBot";
@@ -0,0 +1,8 @@
+ALTER TABLE player
+    ADD COLUMN "isBot" boolean NOT NULL DEFAULT false;
+
+INSERT INTO player ("username", "passwordHash", "isBot")
+VALUES ('computer-easy', '', true),
+       ('computer-medium', '', true),
+       ('computer-hard', '', true)
+ON CONFLICT ("username") DO NOTHING;
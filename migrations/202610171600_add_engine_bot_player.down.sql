@@ -0,0 +1,4 @@
+DELETE
+FROM player
+WHERE "username" = 'computer-engine'
+  AND "isBot" IS TRUE;
@@ -0,0 +1,3 @@
+INSERT INTO player ("username", "passwordHash", "isBot")
+VALUES ('computer-engine', '', true)
+ON CONFLICT ("username") DO NOTHING;
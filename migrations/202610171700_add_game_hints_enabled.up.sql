@@ -0,0 +1,2 @@
+ALTER TABLE game
+    ADD COLUMN "hintsEnabled" boolean NOT NULL DEFAULT TRUE;
@@ -0,0 +1,4 @@
+ALTER TABLE game
+    DROP COLUMN "opening";
+ALTER TABLE game
+    DROP COLUMN "eco";
@@ -0,0 +1,4 @@
+ALTER TABLE game
+    ADD COLUMN "eco" character varying(3);
+ALTER TABLE game
+    ADD COLUMN "opening" character varying;
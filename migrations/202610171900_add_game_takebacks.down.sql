@@ -0,0 +1,6 @@
+ALTER TABLE game
+    DROP CONSTRAINT "FK_game_takeback_player_id";
+ALTER TABLE game
+    DROP COLUMN "takebackPlayerId";
+ALTER TABLE game
+    DROP COLUMN "takebacksEnabled";
@@ -0,0 +1,6 @@
+ALTER TABLE game
+    ADD COLUMN "takebacksEnabled" boolean NOT NULL DEFAULT TRUE;
+ALTER TABLE game
+    ADD COLUMN "takebackPlayerId" bigint;
+ALTER TABLE game
+    ADD CONSTRAINT "FK_game_takeback_player_id" FOREIGN KEY ("takebackPlayerId") REFERENCES "player" ("id") ON DELETE SET NULL ON UPDATE NO ACTION;
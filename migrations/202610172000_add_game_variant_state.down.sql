@@ -0,0 +1,6 @@
+ALTER TABLE game
+    DROP COLUMN "blackChecks";
+ALTER TABLE game
+    DROP COLUMN "whiteChecks";
+ALTER TABLE game
+    DROP COLUMN "pocket";
@@ -0,0 +1,6 @@
+ALTER TABLE game
+    ADD COLUMN "pocket" varchar NOT NULL DEFAULT '';
+ALTER TABLE game
+    ADD COLUMN "whiteChecks" integer NOT NULL DEFAULT 0;
+ALTER TABLE game
+    ADD COLUMN "blackChecks" integer NOT NULL DEFAULT 0;
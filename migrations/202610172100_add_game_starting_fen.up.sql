@@ -0,0 +1,2 @@
+ALTER TABLE game
+    ADD COLUMN "startingFen" varchar NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE "game_position"
+(
+    "id"        SERIAL    NOT NULL,
+    "gameId"    integer   NOT NULL,
+    "ply"       integer   NOT NULL,
+    "hash"      bigint    NOT NULL,
+    "createdAt" TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT "PK_game_position_id" PRIMARY KEY ("id"),
+    CONSTRAINT "UQ_game_position_game_id_ply" UNIQUE ("gameId", "ply"),
+    CONSTRAINT "FK_game_position_game_id" FOREIGN KEY ("gameId") REFERENCES "game" ("id") ON DELETE CASCADE ON UPDATE NO ACTION
+);
+
+CREATE INDEX "IDX_game_position_hash" ON "game_position" ("hash");
@@ -0,0 +1,2 @@
+DROP TABLE "game_move_analysis";
+DROP TABLE "game_analysis";
@@ -0,0 +1,26 @@
+CREATE TABLE "game_analysis"
+(
+    "id"            SERIAL            NOT NULL,
+    "gameId"        integer           NOT NULL,
+    "whiteAccuracy" real              NULL,
+    "blackAccuracy" real              NULL,
+    "error"         character varying NULL,
+    "createdAt"     TIMESTAMP         NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT "PK_game_analysis_id" PRIMARY KEY ("id"),
+    CONSTRAINT "UQ_game_analysis_game_id" UNIQUE ("gameId"),
+    CONSTRAINT "FK_game_analysis_game_id" FOREIGN KEY ("gameId") REFERENCES "game" ("id") ON DELETE CASCADE ON UPDATE NO ACTION
+);
+
+CREATE TABLE "game_move_analysis"
+(
+    "id"             SERIAL                NOT NULL,
+    "gameMoveId"     integer               NOT NULL,
+    "score"          integer               NOT NULL,
+    "bestMove"       character varying(16) NOT NULL,
+    "loss"           integer               NOT NULL,
+    "classification" character varying(16) NOT NULL DEFAULT '',
+    "accuracy"       real                  NOT NULL,
+    CONSTRAINT "PK_game_move_analysis_id" PRIMARY KEY ("id"),
+    CONSTRAINT "UQ_game_move_analysis_game_move_id" UNIQUE ("gameMoveId"),
+    CONSTRAINT "FK_game_move_analysis_game_move_id" FOREIGN KEY ("gameMoveId") REFERENCES "game_move" ("id") ON DELETE CASCADE ON UPDATE NO ACTION
+);
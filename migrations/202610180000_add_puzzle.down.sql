@@ -0,0 +1,6 @@
+ALTER TABLE player
+    DROP COLUMN "puzzleElo";
+
+DROP TABLE "puzzle_attempt";
+
+DROP TABLE "puzzle";
@@ -0,0 +1,31 @@
+CREATE TABLE "puzzle"
+(
+    "id"        SERIAL            NOT NULL,
+    "lichessId" character varying NULL,
+    "fen"       character varying NOT NULL,
+    "solution"  character varying NOT NULL,
+    "rating"    integer           NOT NULL DEFAULT 1500,
+    "themes"    character varying NOT NULL DEFAULT '',
+    "plays"     integer           NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP         NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT "PK_puzzle_id" PRIMARY KEY ("id"),
+    CONSTRAINT "UQ_puzzle_lichess_id" UNIQUE ("lichessId")
+);
+
+CREATE INDEX "IDX_puzzle_rating" ON "puzzle" ("rating");
+
+CREATE TABLE "puzzle_attempt"
+(
+    "id"        SERIAL    NOT NULL,
+    "puzzleId"  integer   NOT NULL,
+    "playerId"  integer   NOT NULL,
+    "solved"    boolean   NOT NULL,
+    "createdAt" TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT "PK_puzzle_attempt_id" PRIMARY KEY ("id"),
+    CONSTRAINT "UQ_puzzle_attempt_puzzle_id_player_id" UNIQUE ("puzzleId", "playerId"),
+    CONSTRAINT "FK_puzzle_attempt_puzzle_id" FOREIGN KEY ("puzzleId") REFERENCES "puzzle" ("id") ON DELETE CASCADE ON UPDATE NO ACTION,
+    CONSTRAINT "FK_puzzle_attempt_player_id" FOREIGN KEY ("playerId") REFERENCES "player" ("id") ON DELETE CASCADE ON UPDATE NO ACTION
+);
+
+ALTER TABLE player
+    ADD COLUMN "puzzleElo" integer NOT NULL DEFAULT 1000;
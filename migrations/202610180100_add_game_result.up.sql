@@ -0,0 +1,5 @@
+ALTER TABLE game
+    ADD COLUMN "result" character varying(7);
+UPDATE game
+    SET "result" = CASE WHEN "winnerId" IS NULL THEN '1/2-1/2' WHEN "winnerId" = "whitePlayerId" THEN '1-0' ELSE '0-1' END
+    WHERE "endedAt" IS NOT NULL;
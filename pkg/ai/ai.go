@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"time"
+)
+
+const (
+	EasyLevel   = "easy"
+	MediumLevel = "medium"
+	HardLevel   = "hard"
+)
+
+// Level godoc
+// The strength of the computer player is limited by the maximum depth of the search and the time budget for a move.
+type Level struct {
+	Name     string
+	Depth    int
+	Duration time.Duration
+}
+
+var Levels = []Level{
+	{Name: EasyLevel, Depth: 1, Duration: 500 * time.Millisecond},
+	{Name: MediumLevel, Depth: 3, Duration: 2 * time.Second},
+	{Name: HardLevel, Depth: 6, Duration: 5 * time.Second},
+}
+
+// FindLevel returns the level with the name
+func FindLevel(name string) (*Level, error) {
+	for _, l := range Levels {
+		if l.Name == name {
+			return &l, nil
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("unknown computer level: %s", name))
+}
+
+// Analyze searches the current position of the game with the search limited by the level
+func Analyze(g *game.Game, level string) (*SearchResult, error) {
+	l, err := FindLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return Search(g.Position(), l.Depth, l.Duration)
+}
+
+// BestMove returns the move of the player on turn in the game found by the search limited by the level, in normalized
+// format accepted by MakeMove
+func BestMove(g *game.Game, level string) (string, error) {
+	result, err := Analyze(g, level)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Move, nil
+}
+
+// MateIn returns the number of moves until the checkmate for the score returned by the search, which is positive when
+// the player on turn is giving the mate and negative when the player is getting mated, or 0 if there is no mate found
+func MateIn(score int) int {
+	if score > MateScore-maxMatePly {
+		return (MateScore - score + 1) / 2
+	}
+	if score < -MateScore+maxMatePly {
+		return -(MateScore + score + 1) / 2
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+	"time"
+)
+
+func TestEvaluateStartingPosition(t *testing.T) {
+	p, _ := game.ParseFEN(game.StartingFEN)
+	utils.AssertTestCondition(t, 0, Evaluate(p), "Starting position should be equal for both players")
+}
+
+func TestEvaluateMaterialAdvantage(t *testing.T) {
+	p, _ := game.ParseFEN("4k3/8/8/8/8/8/8/Q3K3 b - - 0 1")
+	utils.AssertTestCondition(t, true, Evaluate(p) < -800, "Player without the queen should have worse position")
+}
+
+func TestSearchMateInOne(t *testing.T) {
+	p, _ := game.ParseFEN("6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1")
+	result, err := Search(p, 3, time.Second)
+	utils.AssertTestCondition(t, nil, err, "Search should find the move")
+	utils.AssertTestCondition(t, "Ra1a8+", result.Move, "Search should find the back rank mate")
+	utils.AssertTestCondition(t, MateScore-1, result.Score, "Mate in one should have the mate score")
+}
+
+func TestSearchMateInTwo(t *testing.T) {
+	p, _ := game.ParseFEN("7k/8/8/8/8/8/R7/1R4K1 w - - 0 1")
+	result, _ := Search(p, 4, 10*time.Second)
+	utils.AssertTestCondition(t, MateScore-3, result.Score, "Search should find the mate in two")
+	utils.AssertTestCondition(t, 2, MateIn(result.Score), "Score should be the mate in two moves")
+	utils.AssertTestCondition(t, 3, len(result.PrincipalVariation), "Principal variation should end with the mate")
+	utils.AssertTestCondition(t, result.Move, result.PrincipalVariation[0],
+		"Principal variation should start with the best move")
+}
+
+func TestMateIn(t *testing.T) {
+	utils.AssertTestCondition(t, 1, MateIn(MateScore-1), "Player on turn should mate in one move")
+	utils.AssertTestCondition(t, -1, MateIn(-MateScore+2), "Player on turn should get mated in one move")
+	utils.AssertTestCondition(t, 0, MateIn(350), "Score without mate should have no moves until the mate")
+}
+
+func TestSearchCapturesHangingQueen(t *testing.T) {
+	p, _ := game.ParseFEN("4k3/8/8/3q4/8/8/3R4/4K3 w - - 0 1")
+	result, _ := Search(p, 2, time.Second)
+	utils.AssertTestCondition(t, "Rd2xd5", result.Move, "Search should capture the undefended queen")
+}
+
+func TestSearchAvoidsDefendedPawn(t *testing.T) {
+	p, _ := game.ParseFEN("4k3/8/2p5/3p4/8/8/3Q4/4K3 w - - 0 1")
+	result, _ := Search(p, 1, time.Second)
+	utils.AssertTestCondition(t, true, result.Move != "Qd2xd5", "Search should not trade the queen for the pawn")
+}
+
+func TestSearchWithoutLegalMoves(t *testing.T) {
+	p, _ := game.ParseFEN("R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1")
+	_, err := Search(p, 2, time.Second)
+	utils.AssertTestCondition(t, true, err != nil, "Search should fail when there are no legal moves")
+}
+
+func TestBestMove(t *testing.T) {
+	g, _ := game.ReplayGame(game.MakeStartingBoard(), []string{})
+	move, err := BestMove(g, EasyLevel)
+	utils.AssertTestCondition(t, nil, err, "Best move should be found")
+
+	_, _, err = g.MakeMove(move, true)
+	utils.AssertTestCondition(t, nil, err, "Best move should be legal")
+
+	_, err = BestMove(g, "unknown")
+	utils.AssertTestCondition(t, true, err != nil, "Best move should fail for unknown level")
+}
+
+func TestAnalyzeGameBlunder(t *testing.T) {
+	g, _ := game.MakeGameFromFEN("4k3/8/8/3r4/8/8/8/3QK3 w - - 0 1", []string{"Qd1d4", "rd5xd4"})
+	analysis, err := AnalyzeGame(g, EasyLevel)
+	utils.AssertTestCondition(t, nil, err, "Game should be analyzed")
+	utils.AssertTestCondition(t, 2, len(analysis.Moves), "Each move should be analyzed")
+	utils.AssertTestCondition(t, BlunderMove, analysis.Moves[0].Classification, "Hanging queen should be blunder")
+	utils.AssertTestCondition(t, "", analysis.Moves[1].Classification, "Capturing the queen should be good move")
+	utils.AssertTestCondition(t, true, analysis.Moves[1].Score < -300, "Black player should be winning")
+	utils.AssertTestCondition(t, true, analysis.WhiteAccuracy < analysis.BlackAccuracy,
+		"Player who blundered should have lower accuracy")
+}
+
+func TestClassifyMove(t *testing.T) {
+	utils.AssertTestCondition(t, "", classifyMove(20), "Small loss should not be classified")
+	utils.AssertTestCondition(t, InaccuracyMove, classifyMove(60), "Loss should be inaccuracy")
+	utils.AssertTestCondition(t, MistakeMove, classifyMove(150), "Loss should be mistake")
+	utils.AssertTestCondition(t, BlunderMove, classifyMove(400), "Loss should be blunder")
+}
@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"math"
+)
+
+const (
+	InaccuracyMove = "inaccuracy"
+	MistakeMove    = "mistake"
+	BlunderMove    = "blunder"
+)
+
+// The centipawns lost by the move from which it is classified as the inaccuracy, the mistake or the blunder
+const (
+	inaccuracyLoss = 50
+	mistakeLoss    = 100
+	blunderLoss    = 300
+)
+
+// The scores are limited when the loss is calculated, so the moves which only shorten the mate or keep the decisive
+// advantage are not punished
+const maxLossScore = 1000
+
+// MoveAnalysis godoc
+// The score of the position after the move in centipawns from the point of view of the white player, the best move of
+// the position before it in normalized format and the centipawns lost by the move from the point of view of the player
+// who made it. The classification is empty for the good moves.
+type MoveAnalysis struct {
+	Score          int
+	BestMove       string
+	Loss           int
+	Classification string
+	Accuracy       float64
+}
+
+// GameAnalysis godoc
+// The analysis of each move played in the game together with the average accuracy of the moves of each player, which
+// is 0 for the player who has not made any moves.
+type GameAnalysis struct {
+	Moves         []MoveAnalysis
+	WhiteAccuracy float64
+	BlackAccuracy float64
+}
+
+// AnalyzeGame searches every position reached in the game with the search limited by the level and compares the move
+// played in the position with the best move found
+func AnalyzeGame(g *game.Game, level string) (*GameAnalysis, error) {
+	l, err := FindLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	// The scores of the positions are from the point of view of the player on turn
+	scores, bestMoves := make([]int, len(g.Positions)), make([]string, len(g.Positions))
+	for i := range g.Positions {
+		position := &g.Positions[i]
+		if len(game.LegalMoves(position)) == 0 {
+			if position.IsCheck() || position.VariantOutcome() != game.NoOutcome {
+				scores[i] = -MateScore
+			}
+			continue
+		}
+
+		result, e := Search(position, l.Depth, l.Duration)
+		if e != nil {
+			return nil, e
+		}
+		scores[i], bestMoves[i] = result.Score, result.Move
+	}
+
+	analysis := &GameAnalysis{Moves: make([]MoveAnalysis, 0, len(g.Moves))}
+	accuracies := [2][]float64{}
+	for i := range g.Moves {
+		isWhite := g.Positions[i].IsWhiteTurn
+		before, after := scores[i], -scores[i+1]
+		loss := max(0, limitScore(before)-limitScore(after))
+
+		score := after
+		if !isWhite {
+			score = -after
+		}
+
+		accuracy := moveAccuracy(limitScore(before), limitScore(after))
+		side := 0
+		if !isWhite {
+			side = 1
+		}
+		accuracies[side] = append(accuracies[side], accuracy)
+
+		analysis.Moves = append(analysis.Moves, MoveAnalysis{Score: score, BestMove: bestMoves[i], Loss: loss,
+			Classification: classifyMove(loss), Accuracy: accuracy})
+	}
+	analysis.WhiteAccuracy, analysis.BlackAccuracy = average(accuracies[0]), average(accuracies[1])
+
+	return analysis, nil
+}
+
+func classifyMove(loss int) string {
+	switch {
+	case loss >= blunderLoss:
+		return BlunderMove
+	case loss >= mistakeLoss:
+		return MistakeMove
+	case loss >= inaccuracyLoss:
+		return InaccuracyMove
+	}
+	return ""
+}
+
+func limitScore(score int) int {
+	return min(max(score, -maxLossScore), maxLossScore)
+}
+
+// winPercent returns the chance of winning for the score in centipawns, which makes the loss of the same number of
+// centipawns count more in the equal position than in the decided one
+func winPercent(score int) float64 {
+	return 50 + 50*(2/(1+math.Exp(-0.00368208*float64(score)))-1)
+}
+
+// moveAccuracy returns the accuracy of the move from 0 to 100 by the chance of winning lost by the move, where the
+// best move has the accuracy of 100
+func moveAccuracy(before int, after int) float64 {
+	lost := winPercent(before) - winPercent(after)
+	if lost <= 0 {
+		return 100
+	}
+	return min(max(103.1668*math.Exp(-0.04354*lost)-3.1669, 0), 100)
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,154 @@
+package ai
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"strings"
+)
+
+// The figure values in centipawns, where the king has no value because it is never captured
+var figureValues = map[string]int{
+	game.Pawn:   100,
+	game.Knight: 320,
+	game.Bishop: 330,
+	game.Rook:   500,
+	game.Queen:  900,
+	game.King:   0,
+}
+
+// The piece-square tables add the bonus for the figure standing on the tile. They are written from the white player's
+// point of view in the same order as the board, from the rank 8 to the rank 1, and mirrored for the black figures.
+var pieceSquareTables = map[string][8][8]int{
+	game.Pawn: {
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{50, 50, 50, 50, 50, 50, 50, 50},
+		{10, 10, 20, 30, 30, 20, 10, 10},
+		{5, 5, 10, 25, 25, 10, 5, 5},
+		{0, 0, 0, 20, 20, 0, 0, 0},
+		{5, -5, -10, 0, 0, -10, -5, 5},
+		{5, 10, 10, -20, -20, 10, 10, 5},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	},
+	game.Knight: {
+		{-50, -40, -30, -30, -30, -30, -40, -50},
+		{-40, -20, 0, 0, 0, 0, -20, -40},
+		{-30, 0, 10, 15, 15, 10, 0, -30},
+		{-30, 5, 15, 20, 20, 15, 5, -30},
+		{-30, 0, 15, 20, 20, 15, 0, -30},
+		{-30, 5, 10, 15, 15, 10, 5, -30},
+		{-40, -20, 0, 5, 5, 0, -20, -40},
+		{-50, -40, -30, -30, -30, -30, -40, -50},
+	},
+	game.Bishop: {
+		{-20, -10, -10, -10, -10, -10, -10, -20},
+		{-10, 0, 0, 0, 0, 0, 0, -10},
+		{-10, 0, 5, 10, 10, 5, 0, -10},
+		{-10, 5, 5, 10, 10, 5, 5, -10},
+		{-10, 0, 10, 10, 10, 10, 0, -10},
+		{-10, 10, 10, 10, 10, 10, 10, -10},
+		{-10, 5, 0, 0, 0, 0, 5, -10},
+		{-20, -10, -10, -10, -10, -10, -10, -20},
+	},
+	game.Rook: {
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{5, 10, 10, 10, 10, 10, 10, 5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{0, 0, 0, 5, 5, 0, 0, 0},
+	},
+	game.Queen: {
+		{-20, -10, -10, -5, -5, -10, -10, -20},
+		{-10, 0, 0, 0, 0, 0, 0, -10},
+		{-10, 0, 5, 5, 5, 5, 0, -10},
+		{-5, 0, 5, 5, 5, 5, 0, -5},
+		{0, 0, 5, 5, 5, 5, 0, -5},
+		{-10, 5, 5, 5, 5, 5, 0, -10},
+		{-10, 0, 5, 0, 0, 0, 0, -10},
+		{-20, -10, -10, -5, -5, -10, -10, -20},
+	},
+	game.King: {
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-20, -30, -30, -40, -40, -30, -30, -20},
+		{-10, -20, -20, -20, -20, -20, -20, -10},
+		{20, 20, 0, 0, 0, 0, 20, 20},
+		{20, 30, 10, 0, 0, 10, 30, 20},
+	},
+}
+
+// The king should leave the corner and help the pawns once the queens and most of the other figures are gone
+var kingEndgameTable = [8][8]int{
+	{-50, -40, -30, -20, -20, -30, -40, -50},
+	{-30, -20, -10, 0, 0, -10, -20, -30},
+	{-30, -10, 20, 30, 30, 20, -10, -30},
+	{-30, -10, 30, 40, 40, 30, -10, -30},
+	{-30, -10, 30, 40, 40, 30, -10, -30},
+	{-30, -10, 20, 30, 30, 20, -10, -30},
+	{-30, -30, 0, 0, 0, 0, -30, -30},
+	{-50, -30, -30, -30, -30, -30, -30, -50},
+}
+
+// The endgame starts when the figures other than pawns and kings of both players are worth less than two rooks and
+// two bishops
+const endgameMaterial = 2 * (500 + 330)
+
+// Evaluate returns the static score of the position in centipawns from the point of view of the player on turn, as
+// the sum of the figure values and their piece-square bonuses
+func Evaluate(position *game.Position) int {
+	board := &position.Board
+
+	figuresMaterial := 0
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			figure := strings.ToUpper(board[row][col])
+			if figure != game.Empty && figure != game.Pawn {
+				figuresMaterial += figureValues[figure]
+			}
+		}
+	}
+	isEndgame := figuresMaterial <= endgameMaterial
+
+	score := 0
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			if board[row][col] == game.Empty {
+				continue
+			}
+
+			figure, isWhite := strings.ToUpper(board[row][col]), game.IsPlayersFigure(board[row][col], true)
+			tableRow := row
+			if !isWhite {
+				tableRow = 7 - row
+			}
+
+			value := figureValues[figure] + pieceSquareTables[figure][tableRow][col]
+			if figure == game.King && isEndgame {
+				value = kingEndgameTable[tableRow][col]
+			}
+
+			if isWhite {
+				score += value
+			} else {
+				score -= value
+			}
+		}
+	}
+
+	// The figures in the pocket can be dropped on the board at any time, so they are worth their value
+	for _, figure := range position.Pocket {
+		if game.IsPlayersFigure(string(figure), true) {
+			score += figureValues[string(figure)]
+		} else {
+			score -= figureValues[strings.ToUpper(string(figure))]
+		}
+	}
+
+	if !position.IsWhiteTurn {
+		return -score
+	}
+	return score
+}
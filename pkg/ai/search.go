@@ -0,0 +1,219 @@
+package ai
+
+import (
+	"errors"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MateScore is the score of the checkmate, which is reduced by the number of plies needed to reach it so that the
+// faster mate is preferred
+const MateScore = 100000
+
+// The scores closer to MateScore than this number of plies are the checkmates found by the search
+const maxMatePly = 1000
+
+// The quiescence search only follows the captures, so the static evaluation is not done in the middle of an exchange
+const maxQuiescenceDepth = 4
+
+// SearchResult godoc
+// The best move found by the search in normalized format, its score in centipawns from the point of view of the player
+// on turn, the depth of the last completed iteration and the number of searched positions. The principal variation is
+// the sequence of the best moves of both players starting with the best move.
+type SearchResult struct {
+	Move               string
+	Score              int
+	Depth              int
+	Nodes              int
+	PrincipalVariation []string
+}
+
+type searcher struct {
+	deadline   time.Time
+	canTimeout bool
+	isTimeout  bool
+	nodes      int
+	// The best line of moves found from the position at each ply of the current search path
+	pv [][]game.Move
+}
+
+// Search godoc
+// Finds the best move of the player on turn with the alpha-beta search by iterative deepening, where each iteration
+// searches one ply deeper until the maximum depth is reached or the time budget runs out. The first iteration is
+// always completed, while the result of the iteration interrupted by the time budget is discarded.
+func Search(position *game.Position, maxDepth int, duration time.Duration) (*SearchResult, error) {
+	moves := game.LegalMoves(position)
+	if len(moves) == 0 {
+		return nil, errors.New("no legal moves in the position")
+	}
+	orderMoves(position, moves)
+
+	s := searcher{deadline: time.Now().Add(duration), pv: make([][]game.Move, max(maxDepth, 1)+1)}
+	var result *SearchResult
+	for depth := 1; depth <= max(maxDepth, 1); depth++ {
+		s.canTimeout = depth > 1
+		move, score := s.searchRoot(position, moves, depth)
+		if s.isTimeout {
+			break
+		}
+
+		best := *move
+		result = &SearchResult{Move: best.String(), Score: score, Depth: depth, PrincipalVariation: s.principalVariation()}
+		if score >= MateScore-depth || score <= -MateScore+depth {
+			break
+		}
+
+		// The best move of the finished iteration is searched first in the next one for the most cutoffs
+		index := slices.Index(moves, best)
+		moves = append([]game.Move{best}, slices.Delete(moves, index, index+1)...)
+	}
+	result.Nodes = s.nodes
+
+	return result, nil
+}
+
+func (s *searcher) searchRoot(position *game.Position, moves []game.Move, depth int) (*game.Move, int) {
+	alpha, beta := -MateScore-1, MateScore+1
+	bestMove := &moves[0]
+	for i := range moves {
+		next := position.Play(&moves[i])
+		score := -s.alphaBeta(&next, depth-1, 1, -beta, -alpha)
+		if s.isTimeout {
+			return nil, 0
+		}
+		if score > alpha {
+			alpha, bestMove = score, &moves[i]
+			s.updatePrincipalVariation(0, &moves[i])
+		}
+	}
+	return bestMove, alpha
+}
+
+// alphaBeta returns the score of the position from the point of view of the player on turn, where the moves which are
+// worse than alpha for the player or better than beta for the opponent are not searched any further
+func (s *searcher) alphaBeta(position *game.Position, depth int, ply int, alpha int, beta int) int {
+	s.pv[ply] = s.pv[ply][:0]
+	if s.checkTimeout() {
+		return 0
+	}
+
+	moves := game.LegalMoves(position)
+	if len(moves) == 0 {
+		// The game decided by the win condition of the variant is lost like the checkmate
+		if position.IsCheck() || position.VariantOutcome() != game.NoOutcome {
+			return -MateScore + ply
+		}
+		return 0
+	}
+	if position.HalfMoveClock >= 100 {
+		return 0
+	}
+	if depth <= 0 {
+		return s.quiescence(position, moves, ply, alpha, beta, maxQuiescenceDepth)
+	}
+
+	orderMoves(position, moves)
+	for i := range moves {
+		next := position.Play(&moves[i])
+		score := -s.alphaBeta(&next, depth-1, ply+1, -beta, -alpha)
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+			s.updatePrincipalVariation(ply, &moves[i])
+		}
+	}
+	return alpha
+}
+
+// quiescence searches only the captures and promotions until the position is quiet, where the player can also
+// choose not to capture and keep the static score
+func (s *searcher) quiescence(position *game.Position, moves []game.Move, ply int, alpha int, beta int,
+	depth int) int {
+	score := Evaluate(position)
+	if score >= beta {
+		return beta
+	}
+	alpha = max(alpha, score)
+	if depth == 0 || s.checkTimeout() {
+		return alpha
+	}
+
+	orderMoves(position, moves)
+	for i := range moves {
+		if !moves[i].IsCapture && moves[i].PromotedToFigure == "" {
+			break
+		}
+
+		next := position.Play(&moves[i])
+		nextMoves := game.LegalMoves(&next)
+		if len(nextMoves) == 0 {
+			score = 0
+			if next.IsCheck() || next.VariantOutcome() != game.NoOutcome {
+				score = MateScore - ply - 1
+			}
+		} else {
+			score = -s.quiescence(&next, nextMoves, ply+1, -beta, -alpha, depth-1)
+		}
+
+		if score >= beta {
+			return beta
+		}
+		alpha = max(alpha, score)
+	}
+	return alpha
+}
+
+// updatePrincipalVariation sets the best line from the position at the ply to the move followed by the best line
+// found after it
+func (s *searcher) updatePrincipalVariation(ply int, move *game.Move) {
+	line := append(s.pv[ply][:0], *move)
+	if ply+1 < len(s.pv) {
+		line = append(line, s.pv[ply+1]...)
+	}
+	s.pv[ply] = line
+}
+
+func (s *searcher) principalVariation() []string {
+	moves := make([]string, 0)
+	for _, m := range s.pv[0] {
+		moves = append(moves, m.String())
+	}
+	return moves
+}
+
+func (s *searcher) checkTimeout() bool {
+	s.nodes++
+	// The clock is checked only every few positions, since reading it is slower than searching the position
+	if s.canTimeout && !s.isTimeout && s.nodes%256 == 0 && time.Now().After(s.deadline) {
+		s.isTimeout = true
+	}
+	return s.isTimeout
+}
+
+// orderMoves sorts the captures and promotions before the quiet moves, where the captures of the most valuable figures
+// by the least valuable ones are searched first
+func orderMoves(position *game.Position, moves []game.Move) {
+	priority := func(m *game.Move) int {
+		value := 0
+		if m.IsCapture {
+			captured := game.Pawn
+			if !m.IsEnPassant {
+				captured = strings.ToUpper(
+					position.Board[game.BoardRankToRow(m.DestinationRank)][game.BoardFileToColumn(m.DestinationFile)])
+			}
+			value += 10*figureValues[captured] - figureValues[strings.ToUpper(m.Figure)]/10 + 1
+		}
+		if m.PromotedToFigure != "" {
+			value += figureValues[strings.ToUpper(m.PromotedToFigure)]
+		}
+		return value
+	}
+
+	slices.SortStableFunc(moves, func(a game.Move, b game.Move) int {
+		return priority(&b) - priority(&a)
+	})
+}
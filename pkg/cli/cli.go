@@ -3,11 +3,13 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
 	"github.com/lmatosevic/chess-cli/pkg/cli/command"
 	"github.com/lmatosevic/chess-cli/pkg/model"
 	"github.com/urfave/cli/v2"
 	"os"
 	"strings"
+	"time"
 )
 
 func Run() {
@@ -203,6 +205,47 @@ func Run() {
 					return nil
 				},
 			},
+			{
+				Name:    "analyze",
+				Aliases: []string{"a"},
+				Usage:   "analyze the current position of the game with local UCI chess engine",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "engine", Required: true, Usage: "Path to the engine binary (e.g. stockfish)"},
+					&cli.Int64Flag{Name: "gameId", Required: true},
+					&cli.IntFlag{Name: "time", Value: 3000, Usage: "Search time in milliseconds"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					if err := StaticInputs(server, username, password, token, stateless); err != nil {
+						return err
+					}
+
+					analysis, err := command.AnalyzeGame(cCtx.String("engine"), cCtx.Int64("gameId"),
+						time.Duration(cCtx.Int("time"))*time.Millisecond)
+					if err != nil {
+						return err
+					}
+
+					ShowGameAnalysis(analysis)
+					return nil
+				},
+			},
+			{
+				Name:    "autoQueen",
+				Aliases: []string{"q"},
+				Usage:   "always promote pawns to queen in interactive mode instead of asking for the figure",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "disable", Usage: "Ask for the figure to promote the pawn to"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					err := command.SetAutoQueen(!cCtx.Bool("disable"))
+					if err != nil {
+						return err
+					}
+
+					ShowAutoQueenMessage(!cCtx.Bool("disable"))
+					return nil
+				},
+			},
 			{
 				Name:     "game",
 				Aliases:  []string{"g", "games"},
@@ -237,6 +280,9 @@ func Run() {
 						Usage: "show information about the game",
 						Flags: []cli.Flag{
 							&cli.Int64Flag{Name: "gameId", Required: true},
+							&cli.BoolFlag{Name: "fen", Usage: "Show only the current position in FEN"},
+							&cli.BoolFlag{Name: "analysis", Usage: "Show the moves of the ended game annotated by " +
+								"the server analysis"},
 						},
 						Action: func(cCtx *cli.Context) error {
 							if err := StaticInputs(server, username, password, token, stateless); err != nil {
@@ -248,6 +294,21 @@ func Run() {
 								return err
 							}
 
+							if cCtx.Bool("fen") {
+								ShowGameFEN(game)
+								return nil
+							}
+
+							if cCtx.Bool("analysis") {
+								analysis, e := command.EndedGameAnalysis(game.Id)
+								if e != nil {
+									return e
+								}
+
+								ShowEndedGameAnalysis(game, analysis)
+								return nil
+							}
+
 							ShowGameInfo(game, moves)
 							return nil
 						},
@@ -260,6 +321,15 @@ func Run() {
 							&cli.StringFlag{Name: "password", Usage: "Make this game password protected"},
 							&cli.IntFlag{Name: "turnDuration", Usage: "For unlimited duration use -1"},
 							&cli.BoolFlag{Name: "white"},
+							&cli.StringFlag{Name: "variant", Value: "standard", Usage: "Supported variants: standard, chess960, " +
+								"kingofthehill, threecheck, atomic, crazyhouse"},
+							&cli.StringFlag{Name: "computer", Usage: "Play against the computer of level: easy, medium, hard, engine"},
+							&cli.BoolFlag{Name: "noTakebacks", Usage: "Do not allow the players to take back their moves"},
+							&cli.StringFlag{Name: "fen", Usage: "Start the game from the custom position in FEN"},
+							&cli.StringFlag{Name: "tiles", Usage: "Start the game from the custom position of 64 tiles " +
+								"from a8 to h1, where 0 is the empty tile"},
+							&cli.BoolFlag{Name: "blackOnTurn", Usage: "Black player is first on turn in the custom " +
+								"position of tiles"},
 						},
 						Action: func(cCtx *cli.Context) error {
 							if err := StaticInputs(server, username, password, token, stateless); err != nil {
@@ -267,7 +337,9 @@ func Run() {
 							}
 
 							game, err := command.CreateGame(cCtx.String("name"), cCtx.String("password"),
-								int32(cCtx.Int("turnDuration")), cCtx.Bool("white"))
+								int32(cCtx.Int("turnDuration")), cCtx.Bool("white"), cCtx.String("variant"),
+								cCtx.String("computer"), cCtx.Bool("noTakebacks"), cCtx.String("fen"), cCtx.String("tiles"),
+								cCtx.Bool("blackOnTurn"))
 							if err != nil {
 								return err
 							}
@@ -322,14 +394,15 @@ func Run() {
 						Usage: "play move in currently active game",
 						Flags: []cli.Flag{
 							&cli.Int64Flag{Name: "gameId", Required: true},
-							&cli.StringFlag{Name: "move", Required: true, Usage: "Use portable game notation (e.g. Pa2a4)"},
+							&cli.StringFlag{Name: "move", Required: true, Usage: "Use standard algebraic notation (e.g. e4, Nf3)"},
+							&cli.StringFlag{Name: "format", Value: "san", Usage: "Supported formats: san, uci (e.g. e2e4, e7e8q)"},
 						},
 						Action: func(cCtx *cli.Context) error {
 							if err := StaticInputs(server, username, password, token, stateless); err != nil {
 								return err
 							}
 
-							resp, err := command.PlayGameMove(cCtx.Int64("gameId"), cCtx.String("move"))
+							resp, err := command.PlayGameMove(cCtx.Int64("gameId"), cCtx.String("move"), cCtx.String("format"))
 							if err != nil {
 								return err
 							}
@@ -343,6 +416,137 @@ func Run() {
 							return nil
 						},
 					},
+					{
+						Name:  "takeback",
+						Usage: "request to take back your last move or respond to the takeback request of opponent",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{Name: "gameId", Required: true},
+							&cli.BoolFlag{Name: "accept", Usage: "Accept the takeback request of opponent"},
+							&cli.BoolFlag{Name: "decline", Usage: "Decline the takeback request of opponent"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							var g *model.Game
+							var err error
+							if cCtx.Bool("accept") || cCtx.Bool("decline") {
+								g, err = command.RespondGameTakeback(cCtx.Int64("gameId"), cCtx.Bool("accept"))
+							} else {
+								g, err = command.RequestGameTakeback(cCtx.Int64("gameId"))
+							}
+							if err != nil {
+								return err
+							}
+
+							ShowGameTakebackMessage(g, cCtx.Bool("decline"))
+							return nil
+						},
+					},
+					{
+						Name:  "moves",
+						Usage: "show legal moves of the player on turn in the game",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{Name: "gameId", Required: true},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							moves, err := command.GameLegalMoves(cCtx.Int64("gameId"))
+							if err != nil {
+								return err
+							}
+
+							ShowGameLegalMoves(moves)
+							return nil
+						},
+					},
+					{
+						Name:  "hint",
+						Usage: "suggest the move of the player on turn found by the local search",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{Name: "gameId", Required: true},
+							&cli.StringFlag{Name: "level", Value: ai.HardLevel, Usage: "Search level: easy, medium, hard"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							hint, err := command.GameHint(cCtx.Int64("gameId"), cCtx.String("level"))
+							if err != nil {
+								return err
+							}
+
+							ShowGameHint(hint)
+							return nil
+						},
+					},
+					{
+						Name:  "hints",
+						Usage: "enable or disable the hints in the game (admins only)",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{Name: "gameId", Required: true},
+							&cli.BoolFlag{Name: "disable", Usage: "Disable the hints instead of enabling them"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							g, err := command.UpdateGameHints(cCtx.Int64("gameId"), !cCtx.Bool("disable"))
+							if err != nil {
+								return err
+							}
+
+							ShowGameHintsMessage(g)
+							return nil
+						},
+					},
+					{
+						Name:  "export",
+						Usage: "export the game for use in other chess software",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{Name: "gameId", Required: true},
+							&cli.StringFlag{Name: "format", Value: command.PGNExportFormat, Usage: "Supported formats: pgn"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							export, err := command.ExportGame(cCtx.Int64("gameId"), cCtx.String("format"))
+							if err != nil {
+								return err
+							}
+
+							ShowGameExport(export)
+							return nil
+						},
+					},
+					{
+						Name:  "import",
+						Usage: "import finished games from PGN file (admins only)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "file", Required: true, Usage: "Path to the PGN file"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							list, err := command.ImportGames(cCtx.String("file"))
+							if err != nil {
+								return err
+							}
+
+							ShowGameList(list)
+							return nil
+						},
+					},
 					{
 						Name:  "manual",
 						Usage: "Shows the instructions for all types of available moves",
@@ -353,6 +557,40 @@ func Run() {
 					},
 				},
 			},
+			{
+				Name:     "puzzle",
+				Aliases:  []string{"pz", "puzzles"},
+				Usage:    "solve tactics puzzles in interactive mode",
+				Category: "puzzles",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "puzzleId", Usage: "Start with the puzzle instead of the next one"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					return StartPuzzleMode(server, username, password, token, stateless, cCtx.Int64("puzzleId"))
+				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "import",
+						Usage: "import puzzles from Lichess puzzle database CSV file (admins only)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "file", Required: true, Usage: "Path to the CSV file"},
+						},
+						Action: func(cCtx *cli.Context) error {
+							if err := StaticInputs(server, username, password, token, stateless); err != nil {
+								return err
+							}
+
+							result, err := command.ImportPuzzles(cCtx.String("file"))
+							if err != nil {
+								return err
+							}
+
+							ShowPuzzleImportResult(result)
+							return nil
+						},
+					},
+				},
+			},
 			{
 				Name:     "player",
 				Aliases:  []string{"p", "players"},
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/engine"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"time"
+)
+
+type GameAnalysis struct {
+	Engine             string
+	Fen                string
+	Depth              int
+	Score              string
+	BestMove           string
+	PrincipalVariation []string
+}
+
+// AnalyzeGame searches the current position of the game with the local UCI engine, where the score is shown from the
+// white player's point of view and the moves are converted to Standard Algebraic Notation, unless the hints are
+// disabled in the game in progress
+func AnalyzeGame(enginePath string, gameId int64, moveTime time.Duration) (*GameAnalysis, error) {
+	g, _, err := GameInfo(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The finished games can always be analyzed, while the games in progress only when hints are enabled
+	if g.InProgress && !g.HintsEnabled {
+		return nil, errors.New("hints are disabled in this game")
+	}
+	if game.FindVariant(g.Variant) != nil {
+		return nil, errors.New("engine analysis supports only standard chess and Chess960")
+	}
+
+	name, result, err := engine.AnalyzePosition(enginePath, g.Fen, g.Variant == game.Chess960Variant, moveTime)
+	if err != nil {
+		return nil, err
+	}
+
+	gameModel, err := game.MakeGameFromFEN(g.Fen, []string{})
+	if err != nil {
+		return nil, err
+	}
+	isWhite := gameModel.Position().IsWhiteTurn
+
+	pv := result.PrincipalVariation
+	if len(pv) == 0 || pv[0] != result.BestMove {
+		pv = []string{result.BestMove}
+	}
+
+	// The principal variation is converted only until the first move which is not valid in this game
+	moves := make([]string, 0)
+	for i, uci := range pv {
+		move, e := gameModel.UCIToMove(uci, isWhite == (i%2 == 0))
+		if e == nil {
+			move, _, e = gameModel.MakeMove(move, isWhite == (i%2 == 0))
+		}
+		if e != nil {
+			break
+		}
+		moves = append(moves, move)
+	}
+	if len(moves) == 0 {
+		return nil, errors.New(fmt.Sprintf("engine best move %s is not valid in the game", result.BestMove))
+	}
+
+	sanMoves, err := game.MakeSANMoves(g.Fen, moves)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GameAnalysis{Engine: name, Fen: g.Fen, Depth: result.Depth,
+		Score: FormatScore(result.Score, result.MateIn, isWhite), BestMove: sanMoves[0], PrincipalVariation: sanMoves}, nil
+}
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strconv"
+	"strings"
+)
+
+// IsAutoQueenEnabled checks whether the pawns reaching the last rank in interactive mode are promoted to queen without
+// asking the player for the figure
+func IsAutoQueenEnabled() bool {
+	data, err := utils.ReadFromFile(utils.HomeFilePath(AutoQueenFile))
+	if err != nil {
+		return false
+	}
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(string(data)))
+	return enabled
+}
+
+func SetAutoQueen(enabled bool) error {
+	return utils.WriteToFile(utils.HomeFilePath(AutoQueenFile), []byte(strconv.FormatBool(enabled)))
+}
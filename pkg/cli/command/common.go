@@ -1,6 +1,9 @@
 package command
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+)
 
 const HomeDirName = ".chess-cli"
 
@@ -8,6 +11,8 @@ const AccessTokenFile = HomeDirName + "/.access_token"
 
 const ServerHostFile = HomeDirName + "/.server_host"
 
+const AutoQueenFile = HomeDirName + "/.auto_queen"
+
 func BuildQueryParams(page int, size int, sort string, filter string) map[string]string {
 	params := make(map[string]string)
 	if page > 0 {
@@ -24,3 +29,18 @@ func BuildQueryParams(page int, size int, sort string, filter string) map[string
 	}
 	return params
 }
+
+// FormatScore describes the score in centipawns or the number of moves until the checkmate from the point of view of
+// the player on turn as the score from the white player's point of view, e.g. +0.35 or black mates in 2
+func FormatScore(score int, mateIn int, isWhiteTurn bool) string {
+	if !isWhiteTurn {
+		score, mateIn = -score, -mateIn
+	}
+	if mateIn > 0 {
+		return fmt.Sprintf("white mates in %d", mateIn)
+	}
+	if mateIn < 0 {
+		return fmt.Sprintf("black mates in %d", -mateIn)
+	}
+	return fmt.Sprintf("%+.2f", float64(score)/100)
+}
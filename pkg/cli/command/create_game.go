@@ -6,9 +6,12 @@ import (
 	"github.com/lmatosevic/chess-cli/pkg/model"
 )
 
-func CreateGame(name string, password string, turnDuration int32, isWhite bool) (*model.Game, error) {
+func CreateGame(name string, password string, turnDuration int32, isWhite bool, variant string,
+	computerLevel string, disableTakebacks bool, fen string, tiles string, blackOnTurn bool) (*model.Game, error) {
 	resp, err := client.SendRequest[model.Game]("POST", "/v1/games/create", nil,
-		&model.GameCreate{Name: name, Password: password, TurnDurationSeconds: turnDuration, IsWhite: isWhite})
+		&model.GameCreate{Name: name, Password: password, TurnDurationSeconds: turnDuration, IsWhite: isWhite,
+			Variant: variant, VsComputer: computerLevel != "", ComputerLevel: computerLevel,
+			DisableTakebacks: disableTakebacks, Fen: fen, Tiles: tiles, BlackOnTurn: blackOnTurn})
 	if err != nil {
 		return nil, err
 	}
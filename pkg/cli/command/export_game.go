@@ -0,0 +1,27 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+)
+
+const PGNExportFormat = "pgn"
+
+func ExportGame(gameId int64, format string) (string, error) {
+	if format != PGNExportFormat {
+		return "", errors.New(fmt.Sprintf("Unsupported export format: %s", format))
+	}
+
+	resp, err := client.SendRequest[model.GenericResponse]("GET", fmt.Sprintf("/v1/games/%d/pgn", gameId), nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != 200 {
+		return "", errors.New(resp.Error.Error)
+	}
+
+	return resp.Data.Data, nil
+}
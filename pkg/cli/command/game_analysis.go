@@ -0,0 +1,23 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+)
+
+// EndedGameAnalysis returns the analysis of the ended game stored by the server
+func EndedGameAnalysis(gameId int64) (*model.GameAnalysis, error) {
+	resp, err := client.SendRequest[model.GameAnalysis]("GET", fmt.Sprintf("/v1/games/%d/analysis", gameId), nil,
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
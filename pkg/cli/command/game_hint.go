@@ -0,0 +1,64 @@
+package command
+
+import (
+	"errors"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+)
+
+type MoveHint struct {
+	Move               string
+	Score              string
+	Depth              int
+	PrincipalVariation []string
+}
+
+// GameHint suggests the move of the player on turn found by the local search from the current tiles and the move
+// history of the game, unless the hints are disabled in the game
+func GameHint(gameId int64, level string) (*MoveHint, error) {
+	g, moves, err := GameInfo(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.HintsEnabled {
+		return nil, errors.New("hints are disabled in this game")
+	}
+	if !g.InProgress {
+		return nil, errors.New("hints are available only in games in progress")
+	}
+
+	gameMoves := make([]string, 0)
+	for _, m := range moves.Items {
+		gameMoves = append(gameMoves, m.Move)
+	}
+
+	// The starting files of the castling rooks in Chess960, the state of the other variants and the custom starting
+	// position are not known from the tiles, so the game is replayed
+	var gameModel *game.Game
+	if g.StartingFen != "" {
+		gameModel, err = game.ReplayVariantGameFromFEN(g.Variant, g.StartingFen, gameMoves)
+	} else if g.Variant != game.StandardVariant {
+		gameModel, err = game.ReplayVariantGame(g.Variant, int(g.StartingPositionId), gameMoves)
+	} else {
+		gameModel, err = game.MakeGame(g.Tiles, gameMoves)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	position := *gameModel.Position()
+
+	result, err := ai.Analyze(gameModel, level)
+	if err != nil {
+		return nil, err
+	}
+
+	sanMoves, err := position.SANMoves(result.PrincipalVariation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoveHint{Move: sanMoves[0], Score: FormatScore(result.Score, ai.MateIn(result.Score), position.IsWhiteTurn),
+		Depth: result.Depth, PrincipalVariation: sanMoves}, nil
+}
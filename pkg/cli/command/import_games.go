@@ -0,0 +1,27 @@
+package command
+
+import (
+	"errors"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+)
+
+func ImportGames(filePath string) (*model.GameListResponse, error) {
+	pgn, err := utils.ReadFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SendRequest[model.GameListResponse]("POST", "/v1/games/import", nil,
+		&model.GameImport{Pgn: string(pgn)})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
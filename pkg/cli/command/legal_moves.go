@@ -0,0 +1,22 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+)
+
+func GameLegalMoves(gameId int64) (*model.GameLegalMoveListResponse, error) {
+	resp, err := client.SendRequest[model.GameLegalMoveListResponse]("GET",
+		fmt.Sprintf("/v1/games/%d/legal-moves", gameId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
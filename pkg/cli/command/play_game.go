@@ -7,9 +7,9 @@ import (
 	"github.com/lmatosevic/chess-cli/pkg/model"
 )
 
-func PlayGameMove(gameId int64, move string) (*model.GenericResponse, error) {
+func PlayGameMove(gameId int64, move string, format string) (*model.GenericResponse, error) {
 	resp, err := client.SendRequest[model.GenericResponse]("POST", fmt.Sprintf("/v1/games/%d/move", gameId), nil,
-		&model.GameMakeMove{Move: move})
+		&model.GameMakeMove{Move: move, Format: format})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+)
+
+// NextPuzzle returns the puzzle not yet attempted by the player with the rating closest to the puzzle rating of the
+// player
+func NextPuzzle() (*model.Puzzle, error) {
+	resp, err := client.SendRequest[model.Puzzle]("GET", "/v1/puzzles/next", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
+
+func PuzzleInfo(puzzleId int64) (*model.Puzzle, error) {
+	resp, err := client.SendRequest[model.Puzzle]("GET", fmt.Sprintf("/v1/puzzles/%d", puzzleId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
+
+// AttemptPuzzle sends all moves played by the player from the starting position of the puzzle and returns the reply
+// of the opponent or the result of the finished puzzle
+func AttemptPuzzle(puzzleId int64, moves []string) (*model.PuzzleAttemptResult, error) {
+	resp, err := client.SendRequest[model.PuzzleAttemptResult]("POST",
+		fmt.Sprintf("/v1/puzzles/%d/attempt", puzzleId), nil, &model.PuzzleAttempt{Moves: moves})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
+
+func ImportPuzzles(filePath string) (*model.PuzzleImportResult, error) {
+	data, err := utils.ReadFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SendRequest[model.PuzzleImportResult]("POST", "/v1/puzzles/import", nil,
+		&model.PuzzleImport{Csv: string(data)})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
@@ -0,0 +1,35 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+)
+
+func RequestGameTakeback(gameId int64) (*model.Game, error) {
+	resp, err := client.SendRequest[model.Game]("POST", fmt.Sprintf("/v1/games/%d/takeback", gameId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
+
+func RespondGameTakeback(gameId int64, accept bool) (*model.Game, error) {
+	resp, err := client.SendRequest[model.Game]("POST", fmt.Sprintf("/v1/games/%d/takeback/respond", gameId), nil,
+		&model.GameTakebackResponse{Accept: accept})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/client"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+)
+
+func UpdateGameHints(gameId int64, enabled bool) (*model.Game, error) {
+	resp, err := client.SendRequest[model.Game]("PUT", fmt.Sprintf("/v1/games/%d/hints", gameId), nil,
+		&model.GameHints{Enabled: enabled})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(resp.Error.Error)
+	}
+
+	return &resp.Data, nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
 	"github.com/lmatosevic/chess-cli/pkg/cli/command"
 	"github.com/lmatosevic/chess-cli/pkg/game"
 	"github.com/lmatosevic/chess-cli/pkg/model"
@@ -15,6 +16,9 @@ import (
 	"syscall"
 )
 
+// The input entered instead of the move to respond to the takeback request of the opponent
+const takebackInput = "takeback"
+
 type NavigationResult struct {
 	Page       int
 	Sort       string
@@ -306,7 +310,60 @@ out:
 			break
 		}
 
-		g, err := command.CreateGame(name, strings.TrimSpace(password), int32(turnDuration), strings.ToLower(white) == "1")
+		variant := game.StandardVariant
+		variants := map[string]string{"1": game.StandardVariant, "2": game.Chess960Variant,
+			"3": game.KingOfTheHillVariant, "4": game.ThreeCheckVariant, "5": game.AtomicVariant,
+			"6": game.CrazyhouseVariant}
+		for {
+			option, err := utils.ReadStringFromStdin("Choose variant:\n1 -> Standard\n2 -> Chess960\n" +
+				"3 -> King of the Hill\n4 -> Three-check\n5 -> Atomic\n6 -> Crazyhouse:\n\n")
+			if err != nil {
+				fmt.Println(err)
+				break out
+			}
+			if _, found := variants[option]; !found {
+				fmt.Println("Invalid option")
+				continue
+			}
+			variant = variants[option]
+			break
+		}
+
+		computerLevel := ""
+		computerLevels := map[string]string{"2": ai.EasyLevel, "3": ai.MediumLevel, "4": ai.HardLevel,
+			"5": handler.EngineBotLevel}
+		for {
+			option, err := utils.ReadStringFromStdin("Choose opponent:\n1 -> Human\n2 -> Computer (easy)\n" +
+				"3 -> Computer (medium)\n4 -> Computer (hard)\n5 -> Computer (engine):\n\n")
+			if err != nil {
+				fmt.Println(err)
+				break out
+			}
+			if !slices.Contains([]string{"1", "2", "3", "4", "5"}, option) {
+				fmt.Println("Invalid option")
+				continue
+			}
+			computerLevel = computerLevels[option]
+			break
+		}
+
+		disableTakebacks := false
+		for {
+			option, err := utils.ReadStringFromStdin("Allow takebacks:\n1 -> Yes\n2 -> No:\n\n")
+			if err != nil {
+				fmt.Println(err)
+				break out
+			}
+			if !slices.Contains([]string{"1", "2"}, option) {
+				fmt.Println("Invalid option")
+				continue
+			}
+			disableTakebacks = option == "2"
+			break
+		}
+
+		g, err := command.CreateGame(name, strings.TrimSpace(password), int32(turnDuration), strings.ToLower(white) == "1",
+			variant, computerLevel, disableTakebacks, "", "", false)
 		if err != nil {
 			fmt.Println(err)
 			break
@@ -320,7 +377,7 @@ out:
 func playGame(gameId int64, player *model.Player) {
 	joinChan := make(chan bool)
 	turnChan := make(chan bool)
-	sigtermChan := make(chan os.Signal)
+	sigtermChan := make(chan os.Signal, 1)
 
 	signal.Notify(sigtermChan, os.Interrupt, syscall.SIGTERM)
 
@@ -332,6 +389,9 @@ func playGame(gameId int64, player *model.Player) {
 		wait, c, err := command.ListenEvents([]string{handler.GameAnyEvent}, gameId,
 			func(event *model.Event, end func()) {
 				if event.Type == handler.GameEndEvent {
+					if _, reason, found := strings.Cut(event.Data.Payload, ":"); found {
+						fmt.Printf("\nThe game has ended by %s\n", reason)
+					}
 					turnChan <- true
 				}
 				if event.Type == handler.GameMoveEvent && event.Data.PlayerId != player.Id {
@@ -355,6 +415,17 @@ func playGame(gameId int64, player *model.Player) {
 					fmt.Println("Opponent has quit the game")
 					turnChan <- true
 				}
+				if event.Type == handler.GameTakebackEvent && event.Data.PlayerId != player.Id {
+					switch event.Data.Payload {
+					case handler.TakebackRequest:
+						fmt.Printf("\nOpponent has requested a takeback, enter \"%s\" to respond\n", takebackInput)
+					case handler.TakebackAccept:
+						fmt.Println("\nOpponent has accepted the takeback")
+						turnChan <- true
+					case handler.TakebackDecline:
+						fmt.Println("\nOpponent has declined the takeback")
+					}
+				}
 			})
 		if err != nil {
 			fmt.Println(err)
@@ -400,13 +471,13 @@ out:
 		}
 
 		if (len(moves.Items) > 0 && moves.Items[len(moves.Items)-1].PlayerId != player.Id) ||
-			(len(moves.Items) == 0 && side == "white" && g.InProgress) {
+			(len(moves.Items) == 0 && (side == "white") == game.IsWhiteFirstOnTurn(g.StartingFen) && g.InProgress) {
 			go func() {
 				turnChan <- true
 			}()
 		} else {
 			fmt.Println()
-			utils.PrintChessBoard(g.Tiles)
+			ShowGameBoard(g)
 			fmt.Println()
 
 			if g.InProgress {
@@ -432,7 +503,7 @@ out:
 				}
 
 				fmt.Println()
-				utils.PrintChessBoard(g.Tiles)
+				ShowGameBoard(g)
 				fmt.Println()
 
 				if g.EndedAt != "" {
@@ -457,7 +528,23 @@ out:
 						break out
 					}
 
-					_, err = command.PlayGameMove(gameId, move)
+					if move == takebackInput {
+						isAccepted, err := respondTakeback(gameId)
+						if err != nil {
+							fmt.Println(err)
+						} else if isAccepted {
+							break
+						}
+						continue
+					}
+
+					move, err = promotionMove(g, move)
+					if err != nil {
+						fmt.Println(err)
+						continue
+					}
+
+					_, err = command.PlayGameMove(gameId, move, "")
 					if err != nil {
 						fmt.Println(err)
 					} else {
@@ -470,7 +557,7 @@ out:
 			ctrl:
 				for {
 					option, err := utils.ReadStringFromStdin("\nSelect option:\n1 -> Go back\n2 -> Continue playing\n" +
-						"3 -> Show help\n4 -> Surrender\n\n")
+						"3 -> Show help\n4 -> Surrender\n5 -> Show hint\n6 -> Request takeback\n\n")
 					if err != nil {
 						fmt.Println(err)
 						break out
@@ -497,6 +584,23 @@ out:
 						}
 						fmt.Println("You have surrendered")
 						break ctrl
+					case "5":
+						fmt.Println("Searching for the best move...")
+						hint, err := command.GameHint(gameId, ai.HardLevel)
+						if err != nil {
+							fmt.Println(err)
+							break ctrl
+						}
+						ShowGameHint(hint)
+						break ctrl
+					case "6":
+						g, err := command.RequestGameTakeback(gameId)
+						if err != nil {
+							fmt.Println(err)
+							break ctrl
+						}
+						ShowGameTakebackMessage(g, false)
+						break ctrl
 					default:
 						fmt.Println("Invalid option")
 					}
@@ -512,6 +616,74 @@ out:
 	}
 }
 
+// promotionMove adds the figure to promote the pawn to to the pawn move to the last rank entered without it, which is
+// the queen if the automatic promotion to queen is enabled or otherwise the figure chosen by the player
+func promotionMove(g *model.Game, move string) (string, error) {
+	gameModel, err := game.ReplayVariantGameFromFEN(g.Variant, g.Fen, []string{})
+	if err != nil || !gameModel.RequiresPromotion(move) {
+		// The move is validated by the server if the position cannot be recreated
+		return move, nil
+	}
+
+	return choosePromotion(move)
+}
+
+// choosePromotion asks the player for the figure to promote the pawn to, unless the automatic promotion to queen is
+// enabled, which the player can also enable here
+func choosePromotion(move string) (string, error) {
+	if command.IsAutoQueenEnabled() {
+		return game.WithPromotion(move, game.Queen), nil
+	}
+
+	for {
+		option, err := utils.ReadStringFromStdin("Promote pawn to:\n1 -> Queen\n2 -> Rook\n3 -> Bishop\n" +
+			"4 -> Knight\n5 -> Queen, and always promote to queen from now on\n\n")
+		if err != nil {
+			return "", err
+		}
+
+		index, err := strconv.Atoi(option)
+		if err != nil || index < 1 || index > len(game.PromotionFigures)+1 {
+			fmt.Println("Invalid option")
+			continue
+		}
+
+		if index == len(game.PromotionFigures)+1 {
+			err = command.SetAutoQueen(true)
+			if err != nil {
+				return "", err
+			}
+			ShowAutoQueenMessage(true)
+			return game.WithPromotion(move, game.Queen), nil
+		}
+
+		return game.WithPromotion(move, game.PromotionFigures[index-1]), nil
+	}
+}
+
+// respondTakeback asks the player whether to accept the takeback request of the opponent and returns whether it was
+// accepted, after which the opponent is on turn
+func respondTakeback(gameId int64) (bool, error) {
+	for {
+		option, err := utils.ReadStringFromStdin("Accept takeback:\n1 -> Yes\n2 -> No:\n\n")
+		if err != nil {
+			return false, err
+		}
+		if !slices.Contains([]string{"1", "2"}, option) {
+			fmt.Println("Invalid option")
+			continue
+		}
+
+		g, err := command.RespondGameTakeback(gameId, option == "1")
+		if err != nil {
+			return false, err
+		}
+		ShowGameTakebackMessage(g, option == "2")
+
+		return option == "1", nil
+	}
+}
+
 func moveDescription(move string) string {
 	moveDesc := ""
 	if move == game.KingSideCastligMove {
@@ -520,12 +692,21 @@ func moveDescription(move string) string {
 	if move == game.QueenSideCastligMove {
 		moveDesc = "(queen side castling)"
 	}
+	if strings.Contains(move, game.EnPassantSign) {
+		moveDesc = "(en passant)"
+	}
+	if strings.Contains(move, game.DropSign) {
+		moveDesc = "(drop)"
+	}
 	if move == game.DrawOfferMove {
 		moveDesc = "(draw offer)"
 	}
 	if move == game.DrawOfferRejectMove {
 		moveDesc = "(draw offer rejected)"
 	}
+	if move == game.DrawClaimMove {
+		moveDesc = "(draw claim)"
+	}
 	return moveDesc
 }
 
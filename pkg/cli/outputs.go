@@ -3,9 +3,12 @@ package cli
 import (
 	"fmt"
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
+	"github.com/lmatosevic/chess-cli/pkg/cli/command"
 	"github.com/lmatosevic/chess-cli/pkg/game"
 	"github.com/lmatosevic/chess-cli/pkg/model"
 	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
 )
 
 func ShowServerInfo(status *model.Status) {
@@ -24,6 +27,14 @@ func ShowLogoutMessage() {
 	fmt.Println("logout successful")
 }
 
+func ShowAutoQueenMessage(enabled bool) {
+	if enabled {
+		fmt.Println("pawns will be promoted to queen automatically")
+	} else {
+		fmt.Println("you will be asked for the figure to promote the pawn to")
+	}
+}
+
 func ShowPasswordChangeMessage() {
 	fmt.Println("password changed successful")
 }
@@ -38,21 +49,34 @@ func ShowEvent(event *model.Event) {
 
 func ShowGameList(list *model.GameListResponse) {
 	title := fmt.Sprintf("Games | Total: %d | Results: %d", list.TotalCount, list.ResultCount)
-	headers := table.Row{"ID", "Name", "Public", "White Player Username", "Black Player Username", "In progress", "Created at"}
+	headers := table.Row{"ID", "Name", "Public", "White Player Username", "Black Player Username", "Opening", "In progress",
+		"Created at"}
 	rows := make([]table.Row, 0)
 	for _, g := range list.Items {
-		rows = append(rows, table.Row{g.Id, g.Name, g.Public, g.WhitePlayerUsername, g.BlackPlayerUsername, g.InProgress,
-			utils.ToLocalDate(g.CreatedAt)})
+		opening := ""
+		if g.Eco != "" {
+			opening = fmt.Sprintf("%s %s", g.Eco, g.Opening)
+		}
+		rows = append(rows, table.Row{g.Id, g.Name, g.Public, g.WhitePlayerUsername, g.BlackPlayerUsername, opening,
+			g.InProgress, utils.ToLocalDate(g.CreatedAt)})
 	}
 
 	utils.PrintTable(title, headers, rows)
 }
 
+func ShowGameFEN(game *model.Game) {
+	fmt.Println(game.Fen)
+}
+
+func ShowGameExport(export string) {
+	fmt.Print(export)
+}
+
 func ShowGameInfo(game *model.Game, moves *model.GameMoveListResponse) {
 	utils.PrintStruct(game)
 
 	fmt.Println()
-	utils.PrintChessBoard(game.Tiles)
+	ShowGameBoard(game)
 	fmt.Println()
 
 	if len(moves.Items) > 0 {
@@ -87,6 +111,55 @@ func ShowGameInfo(game *model.Game, moves *model.GameMoveListResponse) {
 	fmt.Printf("Status: %s\n", status)
 }
 
+// ShowGameBoard prints the board of the game followed by the rules and the state of its variant
+func ShowGameBoard(g *model.Game) {
+	utils.PrintChessBoard(g.Tiles)
+
+	switch g.Variant {
+	case game.KingOfTheHillVariant:
+		fmt.Println("King of the Hill: the king reaching d4, e4, d5 or e5 wins the game")
+	case game.ThreeCheckVariant:
+		fmt.Printf("Three-check: white has given %d/%d and black %d/%d checks\n", g.WhiteChecks, game.ThreeCheckCount,
+			g.BlackChecks, game.ThreeCheckCount)
+	case game.AtomicVariant:
+		fmt.Println("Atomic: the capture explodes all figures except pawns around the destination tile")
+	case game.CrazyhouseVariant:
+		whitePocket, blackPocket := make([]string, 0), make([]string, 0)
+		for _, f := range g.Pocket {
+			if game.IsPlayersFigure(string(f), true) {
+				whitePocket = append(whitePocket, string(f))
+			} else {
+				blackPocket = append(blackPocket, string(f))
+			}
+		}
+		fmt.Printf("Pocket: white [%s] black [%s]\n", strings.Join(whitePocket, " "), strings.Join(blackPocket, " "))
+	}
+}
+
+func ShowGameHintsMessage(game *model.Game) {
+	state := "disabled"
+	if game.HintsEnabled {
+		state = "enabled"
+	}
+	fmt.Printf("Hints are %s in the game with ID: %d\n", state, game.Id)
+}
+
+func ShowGameTakebackMessage(game *model.Game, isDeclined bool) {
+	if isDeclined {
+		fmt.Println("Takeback request declined")
+		return
+	}
+	if game.TakebackPlayerId != 0 {
+		fmt.Println("Takeback requested, waiting for opponent to respond")
+		return
+	}
+
+	fmt.Println("Move taken back")
+	fmt.Println()
+	ShowGameBoard(game)
+	fmt.Println()
+}
+
 func ShowCreateGameMessage(gameId int64) {
 	fmt.Println("game created with ID: ", gameId)
 }
@@ -103,7 +176,7 @@ func ShowGameMoveResult(move string, game *model.Game, moves *model.GameMoveList
 	fmt.Printf("played move %s", move)
 
 	fmt.Println()
-	utils.PrintChessBoard(game.Tiles)
+	ShowGameBoard(game)
 	fmt.Println()
 
 	if len(moves.Items) > 0 {
@@ -114,15 +187,122 @@ func ShowGameMoveResult(move string, game *model.Game, moves *model.GameMoveList
 	}
 }
 
+func ShowGameLegalMoves(list *model.GameLegalMoveListResponse) {
+	if len(list.Items) == 0 {
+		fmt.Println("no legal moves")
+		return
+	}
+
+	fmt.Printf("Legal moves (%d): ", list.TotalCount)
+	for _, m := range list.Items {
+		fmt.Printf("%s ", m.San)
+	}
+	fmt.Println()
+}
+
+func ShowGameAnalysis(analysis *command.GameAnalysis) {
+	fmt.Printf("Engine: %s\n", analysis.Engine)
+	fmt.Printf("Position: %s\n", analysis.Fen)
+	fmt.Printf("Depth: %d\n", analysis.Depth)
+	fmt.Printf("Score: %s\n", analysis.Score)
+	fmt.Printf("Best move: %s\n", analysis.BestMove)
+	fmt.Printf("Principal variation: %s\n", strings.Join(analysis.PrincipalVariation, " "))
+}
+
+func ShowGameHint(hint *command.MoveHint) {
+	fmt.Printf("Suggested move: %s\n", hint.Move)
+	fmt.Printf("Evaluation: %s (depth %d)\n", hint.Score, hint.Depth)
+	fmt.Printf("Principal variation: %s\n", strings.Join(hint.PrincipalVariation, " "))
+}
+
+// ShowEndedGameAnalysis prints the moves of the game annotated with the inaccuracies (?!), mistakes (?) and
+// blunders (??), the score after each move from the white player's point of view and the accuracy of each player
+func ShowEndedGameAnalysis(g *model.Game, analysis *model.GameAnalysis) {
+	title := fmt.Sprintf("Game analysis | White accuracy: %.1f%% | Black accuracy: %.1f%%", analysis.WhiteAccuracy,
+		analysis.BlackAccuracy)
+	headers := table.Row{"Move", "Score", "Best move", "Loss", "Classification"}
+	rows := make([]table.Row, 0)
+	isWhite := game.IsWhiteFirstOnTurn(g.StartingFen)
+	number := 1
+	for _, m := range analysis.Moves {
+		move := fmt.Sprintf("%d. %s%s", number, m.San, analysisMark(m.Classification))
+		if !isWhite {
+			move = fmt.Sprintf("%d... %s%s", number, m.San, analysisMark(m.Classification))
+			number++
+		}
+
+		score := command.FormatScore(int(m.Score), m.MateIn, true)
+		if strings.HasSuffix(m.San, "#") {
+			score = "checkmate"
+		}
+
+		rows = append(rows, table.Row{move, score, m.BestMove, m.Loss, m.Classification})
+		isWhite = !isWhite
+	}
+
+	utils.PrintTable(title, headers, rows)
+}
+
+func analysisMark(classification string) string {
+	switch classification {
+	case ai.InaccuracyMove:
+		return "?!"
+	case ai.MistakeMove:
+		return "?"
+	case ai.BlunderMove:
+		return "??"
+	}
+	return ""
+}
+
+func ShowPuzzle(p *model.Puzzle) {
+	side := "white"
+	if !p.IsWhiteTurn {
+		side = "black"
+	}
+
+	fmt.Printf("Puzzle %d | Rating: %d | Themes: %s\n\n", p.Id, p.Rating, strings.Join(p.Themes, ", "))
+	utils.PrintChessBoard(p.Tiles)
+	fmt.Printf("\nFind the best move for %s\n", side)
+}
+
+func ShowPuzzleResult(result *model.PuzzleAttemptResult) {
+	if result.Solved {
+		fmt.Println("\nCorrect! You have solved the puzzle")
+	} else {
+		fmt.Println("\nWrong move, you have failed the puzzle")
+	}
+	fmt.Printf("Solution: %s\n", strings.Join(result.Solution, " "))
+	fmt.Printf("Puzzle rating: %d (%+d)\n", result.PlayerRating, result.RatingChange)
+}
+
+func ShowPuzzleImportResult(result *model.PuzzleImportResult) {
+	fmt.Printf("Imported %d of %d puzzles, the others have already been imported\n", result.Imported,
+		result.Parsed)
+}
+
 func ShowGameMovesHelp() {
-	fmt.Print("The move input should be formatted according to the Public Game Notation chess standard:\n")
+	fmt.Print("The move input should be formatted according to the Standard Algebraic Notation chess standard:\n")
+	fmt.Print("(figure*)(file*)(rank*)(capture*)(dest_file)(dest_rank)(=figure_to_promote*)\n")
+	fmt.Print("* - marks the optional parts of the move string, the figure is omitted for pawn moves\n")
+	fmt.Print("Example valid moves: e4, Nf3, exd5, Nbd7, R1a3, Bxc4, h8=Q\n\n")
+	fmt.Print("The file and rank of the figure are required only when more than one figure can make the move\n\n")
+	fmt.Print("The legacy format with mandatory figure and optional figure position is also supported:\n")
 	fmt.Print("(figure)(file*)(rank*)(dest_file)(dest_rank)(figure_to_promote*)\n")
-	fmt.Print("* - marks the optional parts of the move string\n")
 	fmt.Print("Example valid moves: Paa3, Qa3, Nbf3, Bf1c4, Ph7h8Q\n\n")
-	fmt.Printf("King side castling move is marked as %s and queen side castling as %s string\n\n",
-		game.KingSideCastligMove, game.QueenSideCastligMove)
+	fmt.Printf("King side castling move is marked as %s and queen side castling as %s string (or %s and %s)\n\n",
+		game.SANKingSideCastlingMove, game.SANQueenSideCastlingMove, game.KingSideCastligMove,
+		game.QueenSideCastligMove)
+	fmt.Printf("The pawn can capture en passant right after opponents pawn double move by moving diagonally behind it, "+
+		"and such move is marked with %s (e.g. Pe5xd6%s)\n\n", game.EnPassantSign, game.EnPassantSign)
+	fmt.Printf("In Crazyhouse the captured figure can be dropped from the pocket on any empty tile with %s followed by "+
+		"the tile (e.g. N%sf3, %se4 for the pawn)\n\n", game.DropSign, game.DropSign, game.DropSign)
 	fmt.Printf("To make a draw request, use the following sign: %s, and to accept the draw request use also the same "+
 		"sign: %s, or to reject it use: %s\n\n", game.DrawOfferMove, game.DrawOfferMove, game.DrawOfferRejectMove)
+	fmt.Printf("To claim a draw when the same position has been repeated three times or when the last 50 moves of both "+
+		"players were made without any capture or pawn move, use the following sign: %s\n\n", game.DrawClaimMove)
+	fmt.Printf("To request a takeback of your last move, select it from the menu shown on Ctrl+C, and to respond to "+
+		"the takeback request of opponent enter: %s\n\n", takebackInput)
 }
 
 func ShowPlayerList(list *model.PlayerListResponse) {
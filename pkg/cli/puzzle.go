@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/cli/command"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+)
+
+// StartPuzzleMode lets the player solve the puzzles one after another, starting with the puzzle with the ID if it is
+// set, or otherwise with the next puzzle chosen by the server
+func StartPuzzleMode(server string, username string, password string, token string, stateless bool,
+	puzzleId int64) error {
+	if err := InteractiveInputs(server, username, password, token, stateless); err != nil {
+		return err
+	}
+
+	for {
+		var p *model.Puzzle
+		var err error
+		if puzzleId > 0 {
+			p, err = command.PuzzleInfo(puzzleId)
+			puzzleId = 0
+		} else {
+			p, err = command.NextPuzzle()
+		}
+		if err != nil {
+			return err
+		}
+
+		err = solvePuzzle(p)
+		if err != nil {
+			return err
+		}
+
+		option, err := utils.ReadStringFromStdin("\nSelect option: \n1 -> Next puzzle\n2 -> Exit\n\n")
+		if err != nil {
+			return err
+		}
+		if option != "1" {
+			return nil
+		}
+	}
+}
+
+// solvePuzzle validates each move of the player before it is sent to the server together with the previous moves,
+// which then replies with the move of the opponent until the puzzle is solved or failed
+func solvePuzzle(p *model.Puzzle) error {
+	g, err := game.MakeGameFromFEN(p.Fen, []string{})
+	if err != nil {
+		return err
+	}
+
+	side := "white"
+	if !p.IsWhiteTurn {
+		side = "black"
+	}
+
+	fmt.Println()
+	ShowPuzzle(p)
+	fmt.Println()
+
+	moves := make([]string, 0)
+	for {
+		input, err := utils.ReadStringFromStdin(fmt.Sprintf("Enter move (%s): ", side))
+		if err != nil {
+			return err
+		}
+
+		if g.RequiresPromotion(input) {
+			input, err = choosePromotion(input)
+			if err != nil {
+				return err
+			}
+		}
+
+		move, _, err := g.MakeMove(input, p.IsWhiteTurn)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		moves = append(moves, move)
+
+		result, err := command.AttemptPuzzle(p.Id, moves)
+		if err != nil {
+			return err
+		}
+
+		if !result.Correct || result.Solved {
+			ShowPuzzleResult(result)
+			return nil
+		}
+
+		_, _, err = g.MakeMove(result.Reply, !p.IsWhiteTurn)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\nOpponent played move: %s\n\n", result.ReplySan)
+		utils.PrintChessBoard(result.Tiles)
+		fmt.Println()
+	}
+}
@@ -21,7 +21,20 @@ type Game struct {
 	BlackPlayerUsername sql.NullString
 	CreatorId           sql.NullInt64
 	WinnerId            sql.NullInt64
+	Result              sql.NullString
 	Tiles               string
+	Fen                 sql.NullString
+	Variant             string
+	StartingPositionId  int32
+	StartingFen         sql.NullString
+	HintsEnabled        bool
+	Eco                 sql.NullString
+	Opening             sql.NullString
+	TakebacksEnabled    bool
+	TakebackPlayerId    sql.NullInt64
+	Pocket              string
+	WhiteChecks         int32
+	BlackChecks         int32
 	InProgress          bool
 	LastMovePlayedAt    sql.NullTime
 	StartedAt           sql.NullTime
@@ -129,7 +142,9 @@ func CountGames(filter string) (int, error) {
 	return totalCount, nil
 }
 
-func CreateGame(name string, password string, turnDurationSeconds int32, creator *Player, white bool, tiles string) (*Game, error) {
+func CreateGame(name string, password string, turnDurationSeconds int32, creator *Player, white bool, variant string,
+	startingPositionId int32, startingFen sql.NullString, tiles string, fen string, takebacksEnabled bool) (*Game,
+	error) {
 	var passwordHash sql.NullString
 	if password != "" {
 		hash, err := bcrypt.GenerateFromPassword([]byte(password), 6)
@@ -158,9 +173,11 @@ func CreateGame(name string, password string, turnDurationSeconds int32, creator
 
 	row := database.GetConnection().QueryRow(
 		`INSERT INTO game ("name", "passwordHash", "turnDurationSeconds", "tiles", "whitePlayerId", "whitePlayerUsername", 
-                  "blackPlayerId", "blackPlayerUsername", "creatorId") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) 
-        RETURNING id`, name, passwordHash, turnDuration, tiles, whitePlayerId, whitePlayerUsername, blackPlayerId,
-		blackPlayerUsername, creator.Id)
+                  "blackPlayerId", "blackPlayerUsername", "creatorId", "fen", "variant", "startingPositionId", 
+                  "takebacksEnabled", "startingFen") 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`, name, passwordHash,
+		turnDuration, tiles, whitePlayerId, whitePlayerUsername, blackPlayerId, blackPlayerUsername, creator.Id, fen,
+		variant, startingPositionId, takebacksEnabled, startingFen)
 
 	var id int64
 	err := row.Scan(&id)
@@ -171,14 +188,109 @@ func CreateGame(name string, password string, turnDurationSeconds int32, creator
 	return FindGameById(id)
 }
 
+// ImportGame creates the already played game together with all of its moves and positions in a single transaction
+func ImportGame(game *Game, moves []GameMove, positions []GamePosition) (*Game, error) {
+	tx, err := database.GetConnection().Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(
+		`INSERT INTO game ("name", "tiles", "fen", "whitePlayerId", "whitePlayerUsername", "blackPlayerId", 
+                  "blackPlayerUsername", "creatorId", "winnerId", "inProgress", "lastMovePlayedAt", "startedAt", "endedAt", 
+                  "eco", "opening", "result", "variant", "startingFen") 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id`,
+		game.Name, game.Tiles, game.Fen, game.WhitePlayerId, game.WhitePlayerUsername, game.BlackPlayerId,
+		game.BlackPlayerUsername, game.CreatorId, game.WinnerId, game.InProgress, SqlDateFormat(game.LastMovePlayedAt),
+		SqlDateFormat(game.StartedAt), SqlDateFormat(game.EndedAt), game.Eco, game.Opening, game.Result, game.Variant,
+		game.StartingFen)
+
+	var id int64
+	err = row.Scan(&id)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	for _, m := range moves {
+		_, err = tx.Exec(`INSERT INTO game_move ("gameId", "playerId", "move") VALUES ($1, $2, $3)`, id, m.PlayerId,
+			m.Move)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	for _, p := range positions {
+		_, err = tx.Exec(`INSERT INTO game_position ("gameId", "ply", "hash") VALUES ($1, $2, $3)`, id, p.Ply, p.Hash)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return FindGameById(id)
+}
+
 func UpdateGame(game *Game) error {
-	res, err := database.GetConnection().Exec(`UPDATE game SET "name" = $2, "passwordHash" = $3, "turnDurationSeconds" = $4, 
+	return updateGame(database.GetConnection(), game)
+}
+
+// TakeBackGameMoves deletes the move of the game with the ID with all moves played after it and the positions reached
+// after the number of remaining moves, and updates the game to the position before the move in a single transaction
+func TakeBackGameMoves(game *Game, moveId int64, ply int) error {
+	tx, err := database.GetConnection().Begin()
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`DELETE FROM game_move WHERE "gameId" = $1 AND id >= $2`, game.Id, moveId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		_ = tx.Rollback()
+		return errors.New("game move does not exist")
+	}
+
+	_, err = tx.Exec(`DELETE FROM game_position WHERE "gameId" = $1 AND "ply" > $2`, game.Id, ply)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = updateGame(tx, game)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// The game is updated either directly through the connection or within the transaction
+func updateGame(db interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}, game *Game) error {
+	res, err := db.Exec(`UPDATE game SET "name" = $2, "passwordHash" = $3, "turnDurationSeconds" = $4, 
                 "whitePlayerId" = $5, "whitePlayerUsername" = $6, "blackPlayerId" = $7, "blackPlayerUsername" = $8, "creatorId" = $9, 
                 "winnerId" = $10, "tiles" = $11, "inProgress" = $12, "lastMovePlayedAt" = $13, "startedAt" = $14, "endedAt" = $15, 
-                "updatedAt" = $16 WHERE id = $1`,
+                "updatedAt" = $16, "fen" = $17, "hintsEnabled" = $18, "eco" = $19, 
+                "opening" = $20, "takebacksEnabled" = $21, "takebackPlayerId" = $22, "pocket" = $23, "whiteChecks" = $24, 
+                "blackChecks" = $25, "result" = $26 WHERE id = $1`,
 		game.Id, game.Name, game.PasswordHash, game.TurnDurationSeconds, game.WhitePlayerId, game.WhitePlayerUsername,
 		game.BlackPlayerId, game.BlackPlayerUsername, game.CreatorId, game.WinnerId, game.Tiles, game.InProgress,
-		SqlDateFormat(game.LastMovePlayedAt), SqlDateFormat(game.StartedAt), SqlDateFormat(game.EndedAt), utils.ISODateNow())
+		SqlDateFormat(game.LastMovePlayedAt), SqlDateFormat(game.StartedAt), SqlDateFormat(game.EndedAt), utils.ISODateNow(),
+		game.Fen, game.HintsEnabled, game.Eco, game.Opening, game.TakebacksEnabled, game.TakebackPlayerId, game.Pocket,
+		game.WhiteChecks, game.BlackChecks, game.Result)
 	if err != nil {
 		return err
 	}
@@ -233,8 +345,36 @@ func FindInactiveGames() (*[]Game, error) {
 	return &games, nil
 }
 
+// FindBotGames returns the games in progress in which one of the players is the computer player
+func FindBotGames() (*[]Game, error) {
+	rows, err := database.GetConnection().Query(`SELECT * FROM game WHERE "inProgress" IS TRUE AND
+  ("whitePlayerId" IN (SELECT id FROM player WHERE "isBot") OR "blackPlayerId" IN (SELECT id FROM player WHERE "isBot"))`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	games := make([]Game, 0)
+
+	for rows.Next() {
+		g := Game{}
+		err := scanGameRows(rows, &g)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+
+	return &games, nil
+}
+
 func scanGameRows(rows *sql.Rows, g *Game) error {
 	return rows.Scan(&g.Id, &g.Name, &g.PasswordHash, &g.TurnDurationSeconds, &g.WhitePlayerId, &g.BlackPlayerId,
 		&g.CreatorId, &g.WinnerId, &g.Tiles, &g.InProgress, &g.LastMovePlayedAt, &g.StartedAt, &g.EndedAt, &g.CreatedAt,
-		&g.UpdatedAt, &g.WhitePlayerUsername, &g.BlackPlayerUsername)
+		&g.UpdatedAt, &g.WhitePlayerUsername, &g.BlackPlayerUsername, &g.Fen, &g.Variant, &g.StartingPositionId,
+		&g.HintsEnabled, &g.Eco, &g.Opening, &g.TakebacksEnabled, &g.TakebackPlayerId, &g.Pocket, &g.WhiteChecks,
+		&g.BlackChecks, &g.StartingFen, &g.Result)
 }
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lmatosevic/chess-cli/pkg/database"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"time"
+)
+
+// GameAnalysis godoc
+// The analysis of the ended game with the accuracy of each player, or the error if the game could not be analyzed.
+type GameAnalysis struct {
+	Id            int64
+	GameId        int64
+	WhiteAccuracy sql.NullFloat64
+	BlackAccuracy sql.NullFloat64
+	Error         sql.NullString
+	CreatedAt     time.Time
+}
+
+// GameMoveAnalysis godoc
+// The analysis of the game move with the score after it in centipawns from the point of view of the white player, the
+// best move in the position before it in Standard Algebraic Notation and the centipawns lost by the move.
+type GameMoveAnalysis struct {
+	Id             int64
+	GameMoveId     int64
+	Score          int32
+	BestMove       string
+	Loss           int32
+	Classification string
+	Accuracy       float32
+}
+
+func (ga *GameAnalysis) FormatCreatedAt() string {
+	return utils.ISODate(ga.CreatedAt)
+}
+
+// CreateGameAnalysis creates the analysis of the game together with the analysis of all of its moves in a single
+// transaction
+func CreateGameAnalysis(analysis *GameAnalysis, moves []GameMoveAnalysis) error {
+	tx, err := database.GetConnection().Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO game_analysis ("gameId", "whiteAccuracy", "blackAccuracy", "error")
+        VALUES ($1, $2, $3, $4)`, analysis.GameId, analysis.WhiteAccuracy, analysis.BlackAccuracy, analysis.Error)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, m := range moves {
+		_, err = tx.Exec(`INSERT INTO game_move_analysis ("gameMoveId", "score", "bestMove", "loss", "classification",
+            "accuracy") VALUES ($1, $2, $3, $4, $5, $6)`, m.GameMoveId, m.Score, m.BestMove, m.Loss,
+			m.Classification, m.Accuracy)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func FindGameAnalysis(gameId int64) (*GameAnalysis, error) {
+	row := database.GetConnection().QueryRow(`SELECT * FROM game_analysis WHERE "gameId" = $1 LIMIT 1`, gameId)
+
+	ga := GameAnalysis{}
+	err := row.Scan(&ga.Id, &ga.GameId, &ga.WhiteAccuracy, &ga.BlackAccuracy, &ga.Error, &ga.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("game has not been analyzed yet")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ga, nil
+}
+
+// QueryGameMoveAnalyses returns the analysis of the moves of the game in the order in which they were played
+func QueryGameMoveAnalyses(gameId int64) (*[]GameMoveAnalysis, error) {
+	rows, err := database.GetConnection().Query(`SELECT a.* FROM game_move_analysis a
+        INNER JOIN game_move m ON m.id = a."gameMoveId" WHERE m."gameId" = $1 ORDER BY m."createdAt", m.id`, gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	analyses := make([]GameMoveAnalysis, 0)
+
+	for rows.Next() {
+		a := GameMoveAnalysis{}
+		err := rows.Scan(&a.Id, &a.GameMoveId, &a.Score, &a.BestMove, &a.Loss, &a.Classification, &a.Accuracy)
+		if err != nil {
+			return nil, err
+		}
+		analyses = append(analyses, a)
+	}
+
+	return &analyses, nil
+}
+
+// FindNotAnalyzedGames returns the ended games with at least one move which have not been analyzed yet, starting with
+// the most recently ended ones
+func FindNotAnalyzedGames(limit int) (*[]Game, error) {
+	rows, err := database.GetConnection().Query(`SELECT * FROM game WHERE "endedAt" IS NOT NULL
+  AND id NOT IN (SELECT "gameId" FROM game_analysis) AND id IN (SELECT "gameId" FROM game_move)
+  ORDER BY "endedAt" DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	games := make([]Game, 0)
+
+	for rows.Next() {
+		g := Game{}
+		err := scanGameRows(rows, &g)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+
+	return &games, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/database"
+	"strings"
+	"time"
+)
+
+// GamePosition godoc
+// The position reached in the game after the number of moves (ply), identified by its Zobrist hash. The unsigned hash
+// is stored with the same bits as the signed bigint.
+type GamePosition struct {
+	Id        int64
+	GameId    int64
+	Ply       int32
+	Hash      int64
+	CreatedAt time.Time
+}
+
+// CreateGamePosition stores the hash of the position reached in the game after the number of moves, which replaces
+// the position previously stored for the same move
+func CreateGamePosition(gameId int64, ply int, hash uint64) error {
+	_, err := database.GetConnection().Exec(
+		`INSERT INTO game_position ("gameId", "ply", "hash") VALUES ($1, $2, $3)
+        ON CONFLICT ("gameId", "ply") DO UPDATE SET "hash" = EXCLUDED."hash"`, gameId, ply, int64(hash))
+	return err
+}
+
+// QueryGamesByPosition queries the games matching the filter which have passed through the position with the hash
+func QueryGamesByPosition(hash uint64, filter string, page int, size int, sort string) (*[]Game, error) {
+	where, sort, order, args := PrepareQueryParams(filter, page, size, sort)
+	where, args = positionWhere(where, args[:len(args)-2], hash)
+	args = append(args, size, (page-1)*size)
+	rows, err := database.GetConnection().Query(
+		fmt.Sprintf(`SELECT * FROM game %s ORDER BY "%s" %s NULLS LAST LIMIT $%d OFFSET $%d`, where, sort, order,
+			len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	games := make([]Game, 0)
+
+	for rows.Next() {
+		g := Game{}
+		err := scanGameRows(rows, &g)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+
+	return &games, nil
+}
+
+func CountGamesByPosition(hash uint64, filter string) (int, error) {
+	where, _, _, args := PrepareQueryParams(filter, 0, 0, "")
+	where, args = positionWhere(where, args[:len(args)-2], hash)
+	row := database.GetConnection().QueryRow(fmt.Sprintf(`SELECT count(*) FROM game %s`, where), args...)
+
+	var totalCount int
+	err := row.Scan(&totalCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
+
+// positionWhere adds the condition of the position hash to the where clause of the filter
+func positionWhere(where string, args []any, hash uint64) (string, []any) {
+	condition := fmt.Sprintf(`id IN (SELECT "gameId" FROM game_position WHERE "hash" = $%d)`, len(args)+1)
+	if where == "" {
+		where = "WHERE " + condition
+	} else {
+		where = fmt.Sprintf("WHERE (%s) AND %s", strings.TrimPrefix(where, "WHERE"), condition)
+	}
+	return where, append(args, int64(hash))
+}
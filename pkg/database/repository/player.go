@@ -19,8 +19,10 @@ type Player struct {
 	Draws        int32
 	Rate         float32
 	Elo          int32
+	PuzzleElo    int32
 	LastPlayedAt sql.NullTime
 	IsPlaying    bool
+	IsBot        bool
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -165,9 +167,9 @@ func CreatePlayer(username string, password string) (*Player, error) {
 func UpdatePlayer(player *Player) error {
 	res, err := database.GetConnection().Exec(`UPDATE player SET "username" = $2, "passwordHash" = $3, "wins" = $4, 
                   "losses" = $5, "draws" = $6, "rate" = $7, "elo" = $8, "lastPlayedAt" = $9, "isPlaying" = $10, 
-                  "updatedAt" = $11 WHERE id = $1`,
+                  "updatedAt" = $11, "puzzleElo" = $12 WHERE id = $1`,
 		player.Id, player.Username, player.PasswordHash, player.Wins, player.Losses, player.Draws, player.Rate,
-		player.Elo, SqlDateFormat(player.LastPlayedAt), player.IsPlaying, utils.ISODateNow())
+		player.Elo, SqlDateFormat(player.LastPlayedAt), player.IsPlaying, utils.ISODateNow(), player.PuzzleElo)
 	if err != nil {
 		return err
 	}
@@ -196,5 +198,5 @@ func DeletePlayer(id int64) error {
 
 func scanPlayerRows(rows *sql.Rows, p *Player) error {
 	return rows.Scan(&p.Id, &p.Username, &p.PasswordHash, &p.Wins, &p.Losses, &p.Draws, &p.Rate, &p.Elo,
-		&p.LastPlayedAt, &p.CreatedAt, &p.UpdatedAt, &p.IsPlaying)
+		&p.LastPlayedAt, &p.CreatedAt, &p.UpdatedAt, &p.IsPlaying, &p.IsBot, &p.PuzzleElo)
 }
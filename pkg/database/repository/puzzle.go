@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lmatosevic/chess-cli/pkg/database"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"time"
+)
+
+// Puzzle godoc
+// The tactics puzzle starting from the position in FEN, with the solution in space separated normalized moves and
+// the space separated themes. The Lichess ID is set for the puzzles imported from the Lichess puzzle database.
+type Puzzle struct {
+	Id        int64
+	LichessId sql.NullString
+	Fen       string
+	Solution  string
+	Rating    int32
+	Themes    string
+	Plays     int32
+	CreatedAt time.Time
+}
+
+type PuzzleAttempt struct {
+	Id        int64
+	PuzzleId  int64
+	PlayerId  int64
+	Solved    bool
+	CreatedAt time.Time
+}
+
+func (p *Puzzle) FormatCreatedAt() string {
+	return utils.ISODate(p.CreatedAt)
+}
+
+// CreatePuzzles creates the puzzles in a single transaction and returns the number of created puzzles, where the
+// puzzles with the Lichess ID which has already been imported are skipped
+func CreatePuzzles(puzzles []Puzzle) (int, error) {
+	tx, err := database.GetConnection().Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, p := range puzzles {
+		res, e := tx.Exec(`INSERT INTO puzzle ("lichessId", "fen", "solution", "rating", "themes")
+            VALUES ($1, $2, $3, $4, $5) ON CONFLICT ("lichessId") DO NOTHING`, p.LichessId, p.Fen, p.Solution,
+			p.Rating, p.Themes)
+		if e != nil {
+			_ = tx.Rollback()
+			return 0, e
+		}
+
+		affected, _ := res.RowsAffected()
+		created += int(affected)
+	}
+
+	return created, tx.Commit()
+}
+
+func FindPuzzleById(id int64) (*Puzzle, error) {
+	rows, err := database.GetConnection().Query(`SELECT * FROM puzzle WHERE id = $1 LIMIT 1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	p := Puzzle{}
+
+	for rows.Next() {
+		err := scanPuzzleRows(rows, &p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Id == 0 {
+		return nil, errors.New("puzzle does not exist")
+	}
+
+	return &p, nil
+}
+
+// FindNextPuzzle returns the random puzzle not yet attempted by the player with the rating closest to the rating of
+// the player, in steps of 100 rating points
+func FindNextPuzzle(playerId int64, rating int32) (*Puzzle, error) {
+	rows, err := database.GetConnection().Query(`SELECT * FROM puzzle
+  WHERE id NOT IN (SELECT "puzzleId" FROM puzzle_attempt WHERE "playerId" = $1)
+  ORDER BY abs("rating" - $2) / 100, random() LIMIT 1`, playerId, rating)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	p := Puzzle{}
+
+	for rows.Next() {
+		err := scanPuzzleRows(rows, &p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Id == 0 {
+		return nil, errors.New("there are no more puzzles to solve")
+	}
+
+	return &p, nil
+}
+
+func UpdatePuzzle(puzzle *Puzzle) error {
+	res, err := database.GetConnection().Exec(`UPDATE puzzle SET "fen" = $2, "solution" = $3, "rating" = $4,
+                  "themes" = $5, "plays" = $6 WHERE id = $1`,
+		puzzle.Id, puzzle.Fen, puzzle.Solution, puzzle.Rating, puzzle.Themes, puzzle.Plays)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return errors.New("puzzle does not exist")
+	}
+
+	return nil
+}
+
+func CreatePuzzleAttempt(puzzleId int64, playerId int64, solved bool) error {
+	_, err := database.GetConnection().Exec(
+		`INSERT INTO puzzle_attempt ("puzzleId", "playerId", "solved") VALUES ($1, $2, $3)`, puzzleId, playerId, solved)
+	return err
+}
+
+// FindPuzzleAttempt returns the first finished attempt of the player to solve the puzzle, or nil if the player has
+// not finished the puzzle yet
+func FindPuzzleAttempt(puzzleId int64, playerId int64) (*PuzzleAttempt, error) {
+	row := database.GetConnection().QueryRow(
+		`SELECT * FROM puzzle_attempt WHERE "puzzleId" = $1 AND "playerId" = $2 LIMIT 1`, puzzleId, playerId)
+
+	a := PuzzleAttempt{}
+	err := row.Scan(&a.Id, &a.PuzzleId, &a.PlayerId, &a.Solved, &a.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func scanPuzzleRows(rows *sql.Rows, p *Puzzle) error {
+	return rows.Scan(&p.Id, &p.LichessId, &p.Fen, &p.Solution, &p.Rating, &p.Themes, &p.Plays, &p.CreatedAt)
+}
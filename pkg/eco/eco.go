@@ -0,0 +1,119 @@
+package eco
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"strings"
+	"sync"
+)
+
+// The table of openings by the Encyclopaedia of Chess Openings with the code, the name and the moves in Standard
+// Algebraic Notation separated by tabs, where the first line is the header. The table is partial, it contains the
+// main lines of the most common openings instead of every line of the A00-E99 codes, so the game is classified by the
+// deepest of them it has reached.
+//
+//go:embed openings.tsv
+var openingsTable string
+
+// Opening godoc
+// The opening from the ECO table with its code (e.g. B90), name and the moves which lead to it in normalized format.
+type Opening struct {
+	Code  string
+	Name  string
+	Moves []string
+}
+
+var (
+	// The openings by the key of the position reached after their moves, so the transpositions are recognized too
+	openings map[string]*Opening
+	// The number of moves in the longest opening of the table
+	maxPly   int
+	loadOnce sync.Once
+	loadErr  error
+)
+
+// Classify returns the opening of the deepest position from the table reached in the game, or nil if the game has not
+// reached any of them. The game must be replayed from the standard starting position, so all its positions are known.
+func Classify(g *game.Game) (*Opening, error) {
+	loadOnce.Do(loadOpenings)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	for i := min(len(g.Positions)-1, maxPly); i > 0; i-- {
+		if o, found := openings[positionKey(&g.Positions[i])]; found {
+			return o, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// MaxPly returns the number of moves after which the classification of the game no longer changes
+func MaxPly() int {
+	loadOnce.Do(loadOpenings)
+	return maxPly
+}
+
+func loadOpenings() {
+	openings = make(map[string]*Opening)
+
+	lines := strings.Split(strings.TrimSpace(openingsTable), "\n")
+	for i, line := range lines[1:] {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			loadErr = errors.New(fmt.Sprintf("invalid ECO table line %d: %s", i+2, line))
+			return
+		}
+
+		o, g, err := parseOpening(fields[0], fields[1], fields[2])
+		if err != nil {
+			loadErr = errors.New(fmt.Sprintf("invalid ECO table line %d: %s", i+2, err.Error()))
+			return
+		}
+
+		// The first of the openings reaching the same position is kept
+		key := positionKey(g.Position())
+		if _, found := openings[key]; !found {
+			openings[key] = o
+		}
+		maxPly = max(maxPly, len(o.Moves))
+	}
+}
+
+// parseOpening plays the moves in Standard Algebraic Notation with move numbers (e.g. 1. e4 c5 2. Nf3) from the
+// standard starting position and returns the opening together with the game in which they were played
+func parseOpening(code string, name string, pgn string) (*Opening, *game.Game, error) {
+	g, err := game.ReplayGame(game.MakeStartingBoard(), []string{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moves := make([]string, 0)
+	isWhite := true
+	for _, san := range strings.Fields(pgn) {
+		if strings.HasSuffix(san, ".") {
+			continue
+		}
+
+		move, e := g.SANToMove(san, isWhite)
+		if e == nil {
+			move, _, e = g.MakeMove(move, isWhite)
+		}
+		if e != nil {
+			return nil, nil, e
+		}
+		moves = append(moves, move)
+		isWhite = !isWhite
+	}
+
+	return &Opening{Code: code, Name: name, Moves: moves}, g, nil
+}
+
+// positionKey returns the figure placement, the player on turn and the castling rights of the position in
+// Forsyth-Edwards Notation, which identify the position regardless of the move order which has led to it
+func positionKey(position *game.Position) string {
+	return strings.Join(strings.Fields(position.FEN())[:3], " ")
+}
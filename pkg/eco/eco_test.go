@@ -0,0 +1,46 @@
+package eco
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+)
+
+func classifySAN(t *testing.T, pgn string) *Opening {
+	_, g, err := parseOpening("", "", pgn)
+	utils.AssertTestCondition(t, nil, err, "Moves should be played: "+pgn)
+
+	o, err := Classify(g)
+	utils.AssertTestCondition(t, nil, err, "ECO table should be loaded")
+	return o
+}
+
+func TestLoadOpenings(t *testing.T) {
+	loadOnce.Do(loadOpenings)
+	utils.AssertTestCondition(t, nil, loadErr, "All openings in ECO table should be valid")
+	utils.AssertTestCondition(t, true, len(openings) > 100, "ECO table should contain the openings")
+	utils.AssertTestCondition(t, 16, MaxPly(), "Longest opening should be the Marshall Attack")
+}
+
+func TestClassifyOpening(t *testing.T) {
+	o := classifySAN(t, "1. e4 c5 2. Nf3 d6 3. d4 cxd4 4. Nxd4 Nf6 5. Nc3 a6")
+	utils.AssertTestCondition(t, "B90", o.Code, "Game should be classified as the Najdorf")
+	utils.AssertTestCondition(t, "Sicilian Defense: Najdorf Variation", o.Name, "Game should have the opening name")
+}
+
+func TestClassifyAfterOpening(t *testing.T) {
+	o := classifySAN(t, "1. e4 e5 2. Nf3 Nc6 3. Bb5 a6 4. Ba4 Nf6 5. O-O Be7 6. Re1 b5 7. Bb3 d6 8. c3 O-O 9. h3")
+	utils.AssertTestCondition(t, "C88", o.Code, "Game should keep the deepest opening reached")
+}
+
+func TestClassifyTransposition(t *testing.T) {
+	o := classifySAN(t, "1. c4 Nf6 2. d4 g6")
+	utils.AssertTestCondition(t, "E60", o.Code, "Transposition to the King's Indian Defense should be recognized")
+}
+
+func TestClassifyUnknownOpening(t *testing.T) {
+	g, _ := game.ReplayGame(game.MakeStartingBoard(), []string{})
+	o, err := Classify(g)
+	utils.AssertTestCondition(t, nil, err, "ECO table should be loaded")
+	utils.AssertTestCondition(t, true, o == nil, "Game without moves should have no opening")
+}
@@ -0,0 +1,270 @@
+package engine
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The time the engine has to respond to the commands other than search, and to finish the search after its time
+// budget has run out
+const responseTimeout = 5 * time.Second
+
+// Engine godoc
+// The client of the chess engine process which speaks the Universal Chess Interface protocol over its standard input
+// and output. The engine is started with Start and must be stopped with Close.
+type Engine struct {
+	Name   string
+	Author string
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	lines  chan string
+	// The done channel is closed by Close, after which the output of the engine is no longer passed to the lines
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// SearchResult godoc
+// The best move in long algebraic notation and the last search info reported by the engine. The score is given in
+// centipawns from the point of view of the player on turn, unless the engine has found the mate in the number of moves
+// given by MateIn, which is negative when the player on turn is getting mated.
+type SearchResult struct {
+	BestMove           string
+	Score              int
+	MateIn             int
+	Depth              int
+	PrincipalVariation []string
+}
+
+// Start runs the engine binary at the path and waits until it is initialized and ready to search
+func Start(path string) (*Engine, error) {
+	cmd := exec.Command(path)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("cannot start engine %s: %s", path, err.Error()))
+	}
+
+	e := &Engine{cmd: cmd, stdin: stdin, lines: make(chan string, 100), done: make(chan struct{})}
+	go func() {
+		// The output is read until the engine exits even after it is closed, so the engine is never blocked on writing
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case e.lines <- strings.TrimSpace(scanner.Text()):
+			case <-e.done:
+			}
+		}
+		close(e.lines)
+	}()
+
+	err = e.send("uci")
+	if err == nil {
+		err = e.readUntil("uciok", responseTimeout, func(line string) {
+			if name, found := strings.CutPrefix(line, "id name "); found {
+				e.Name = name
+			} else if author, found := strings.CutPrefix(line, "id author "); found {
+				e.Author = author
+			}
+		})
+	}
+	if err == nil {
+		err = e.IsReady()
+	}
+	if err != nil {
+		_ = e.Close()
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// IsReady waits until the engine has processed all commands sent so far
+func (e *Engine) IsReady() error {
+	err := e.send("isready")
+	if err != nil {
+		return err
+	}
+	return e.readUntil("readyok", responseTimeout, nil)
+}
+
+// SetOption sets the value of the option supported by the engine (e.g. Skill Level or UCI_Chess960)
+func (e *Engine) SetOption(name string, value string) error {
+	return e.send(fmt.Sprintf("setoption name %s value %s", name, value))
+}
+
+// NewGame tells the engine that the next position is from a different game
+func (e *Engine) NewGame() error {
+	err := e.send("ucinewgame")
+	if err != nil {
+		return err
+	}
+	return e.IsReady()
+}
+
+// SetPosition sets the position in Forsyth-Edwards Notation after which the moves in long algebraic notation were
+// played
+func (e *Engine) SetPosition(fen string, moves []string) error {
+	command := fmt.Sprintf("position fen %s", fen)
+	if len(moves) > 0 {
+		command += " moves " + strings.Join(moves, " ")
+	}
+	return e.send(command)
+}
+
+// Search lets the engine search the set position for the duration and returns its best move. The search is stopped
+// if the engine has not responded within its time budget.
+func (e *Engine) Search(duration time.Duration) (*SearchResult, error) {
+	err := e.send(fmt.Sprintf("go movetime %d", duration.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	result := SearchResult{}
+	onInfo := func(line string) {
+		if strings.HasPrefix(line, "info ") {
+			parseInfo(line, &result)
+		} else if move, found := strings.CutPrefix(line, "bestmove "); found {
+			result.BestMove = strings.Fields(move)[0]
+		}
+	}
+
+	err = e.readUntil("bestmove", duration+time.Second, onInfo)
+	if err != nil {
+		err = e.send("stop")
+		if err == nil {
+			err = e.readUntil("bestmove", responseTimeout, onInfo)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.BestMove == "" || result.BestMove == "(none)" {
+		return nil, errors.New("engine has not found any move")
+	}
+
+	return &result, nil
+}
+
+// Close asks the engine to quit and kills its process if it does not exit in time
+func (e *Engine) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+	_ = e.send("quit")
+	_ = e.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.cmd.Wait()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(responseTimeout):
+		return e.cmd.Process.Kill()
+	}
+}
+
+func (e *Engine) send(command string) error {
+	_, err := io.WriteString(e.stdin, command+"\n")
+	return err
+}
+
+// readUntil reads the lines of the engine output until the line starting with the prefix, which are all passed to
+// the callback including the last one
+func (e *Engine) readUntil(prefix string, timeout time.Duration, onLine func(line string)) error {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return errors.New("engine has exited unexpectedly")
+			}
+			if onLine != nil {
+				onLine(line)
+			}
+			if line == prefix || strings.HasPrefix(line, prefix+" ") {
+				return nil
+			}
+		case <-deadline:
+			return errors.New(fmt.Sprintf("engine has not responded with %s in time", prefix))
+		}
+	}
+}
+
+// parseInfo updates the result with the depth, score and principal variation from the info line, e.g.
+// info depth 12 seldepth 18 score cp 34 nodes 123456 pv e2e4 e7e5 g1f3
+func parseInfo(line string, result *SearchResult) {
+	fields := strings.Fields(line)
+	for i := 1; i < len(fields); i++ {
+		switch fields[i] {
+		case "depth":
+			if i+1 < len(fields) {
+				result.Depth, _ = strconv.Atoi(fields[i+1])
+			}
+		case "score":
+			if i+2 < len(fields) {
+				value, _ := strconv.Atoi(fields[i+2])
+				if fields[i+1] == "mate" {
+					result.MateIn, result.Score = value, 0
+				} else if fields[i+1] == "cp" {
+					result.MateIn, result.Score = 0, value
+				}
+			}
+		case "pv":
+			result.PrincipalVariation = fields[i+1:]
+			return
+		}
+	}
+}
+
+// AnalyzePosition starts the engine at the path, searches the position in Forsyth-Edwards Notation for the duration and
+// quits the engine. Returns the name of the engine and its search result.
+func AnalyzePosition(path string, fen string, isChess960 bool, duration time.Duration) (string, *SearchResult,
+	error) {
+	e, err := Start(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func(e *Engine) {
+		_ = e.Close()
+	}(e)
+
+	if isChess960 {
+		err = e.SetOption("UCI_Chess960", "true")
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	err = e.NewGame()
+	if err == nil {
+		err = e.SetPosition(fen, []string{})
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	result, err := e.Search(duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return e.Name, result, nil
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const fakeEngineEnv = "CHESS_CLI_FAKE_ENGINE"
+
+// The test binary runs itself as the fake engine when the environment variable is set, which plays the first legal
+// move in the position. In the slow mode it does not stop the search until it is told to.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeEngineEnv); mode != "" {
+		runFakeEngine(mode == "slow")
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func runFakeEngine(isSlow bool) {
+	position, _ := game.ParseFEN(game.StartingFEN)
+	bestMove := func() string {
+		moves := game.LegalMoves(position)
+		if len(moves) == 0 {
+			return "(none)"
+		}
+		uci, _ := game.MoveToUCI(moves[0].String(), position.IsWhiteTurn, position.CastlingFiles)
+		return uci
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "uci":
+			fmt.Println("id name Fake Engine 1.0")
+			fmt.Println("id author Chess CLI")
+			fmt.Println("option name UCI_Chess960 type check default false")
+			fmt.Println("uciok")
+		case line == "isready":
+			fmt.Println("readyok")
+		case strings.HasPrefix(line, "position fen "):
+			fen, _, _ := strings.Cut(strings.TrimPrefix(line, "position fen "), " moves ")
+			position, _ = game.ParseFEN(fen)
+		case strings.HasPrefix(line, "go"):
+			fmt.Println("info depth 1 seldepth 1 score cp 13 nodes 20 pv " + bestMove())
+			if !isSlow {
+				fmt.Println("info depth 2 score mate 3 nodes 400 pv " + bestMove() + " e7e5")
+				fmt.Println("bestmove " + bestMove())
+			}
+		case line == "stop":
+			fmt.Println("bestmove " + bestMove())
+		case line == "quit":
+			return
+		}
+	}
+}
+
+func startFakeEngine(t *testing.T, mode string) *Engine {
+	t.Setenv(fakeEngineEnv, mode)
+	e, err := Start(os.Args[0])
+	utils.AssertTestCondition(t, nil, err, "Engine should be started without error")
+	return e
+}
+
+func TestStartEngine(t *testing.T) {
+	e := startFakeEngine(t, "fast")
+	utils.AssertTestCondition(t, "Fake Engine 1.0", e.Name, "Engine name should be read")
+	utils.AssertTestCondition(t, "Chess CLI", e.Author, "Engine author should be read")
+	utils.AssertTestCondition(t, nil, e.Close(), "Engine should quit without error")
+}
+
+func TestStartMissingEngine(t *testing.T) {
+	_, err := Start("/nonexistent/engine")
+	utils.AssertTestCondition(t, true, err != nil, "Missing engine should not be started")
+}
+
+func TestEngineSearch(t *testing.T) {
+	e := startFakeEngine(t, "fast")
+	defer e.Close()
+
+	_ = e.NewGame()
+	_ = e.SetPosition("4k3/8/8/8/8/8/8/R3K3 w - - 0 1", []string{})
+	result, err := e.Search(100 * time.Millisecond)
+	utils.AssertTestCondition(t, nil, err, "Search should return the best move")
+	utils.AssertTestCondition(t, "a1a8", result.BestMove, "Best move should be read")
+	utils.AssertTestCondition(t, 2, result.Depth, "Depth of the last info should be read")
+	utils.AssertTestCondition(t, 3, result.MateIn, "Mate score should be read")
+	utils.AssertTestCondition(t, "a1a8 e7e5", strings.Join(result.PrincipalVariation, " "),
+		"Principal variation should be read")
+}
+
+func TestEngineSearchStoppedAfterTimeBudget(t *testing.T) {
+	e := startFakeEngine(t, "slow")
+	defer e.Close()
+
+	start := time.Now()
+	result, err := e.Search(100 * time.Millisecond)
+	utils.AssertTestCondition(t, nil, err, "Stopped search should return the best move")
+	utils.AssertTestCondition(t, 13, result.Score, "Score of the last info should be read")
+	utils.AssertTestCondition(t, true, time.Since(start) < 3*time.Second, "Search should be stopped in time")
+}
+
+func TestAnalyzePosition(t *testing.T) {
+	t.Setenv(fakeEngineEnv, "fast")
+	name, result, err := AnalyzePosition(os.Args[0], game.StartingFEN, false, 100*time.Millisecond)
+	utils.AssertTestCondition(t, nil, err, "Position should be analyzed without error")
+	utils.AssertTestCondition(t, "Fake Engine 1.0", name, "Engine name should be returned")
+
+	g, _ := game.MakeGameFromFEN(game.StartingFEN, []string{})
+	_, err = g.UCIToMove(result.BestMove, true)
+	utils.AssertTestCondition(t, nil, err, "Best move should be legal")
+}
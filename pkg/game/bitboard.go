@@ -0,0 +1,200 @@
+package game
+
+import (
+	"math/bits"
+)
+
+// The tiles of the bitboards are indexed in the same order as the tiles of the board, from a8 (0) to h1 (63), so the
+// row of the tile is index / 8 and the column is index % 8
+const (
+	whiteSide = 0
+	blackSide = 1
+)
+
+const (
+	noFigureType = iota - 1
+	pawnType
+	knightType
+	bishopType
+	rookType
+	queenType
+	kingType
+)
+
+var figureTypes = [6]string{Pawn, Knight, Bishop, Rook, Queen, King}
+
+var (
+	knightOffsets    = [][2]int{{-2, -1}, {-2, 1}, {-1, -2}, {-1, 2}, {1, -2}, {1, 2}, {2, -1}, {2, 1}}
+	kingOffsets      = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+	rookDirections   = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	bishopDirections = [][2]int{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+)
+
+// The attack tables are precomputed for every tile, where the pawn attacks are indexed by the side of the pawn and
+// the rays contain all tiles in the direction until the edge of the board
+var (
+	knightAttacks = makeOffsetAttacks(knightOffsets)
+	kingAttacks   = makeOffsetAttacks(kingOffsets)
+	pawnAttacks   = [2][64]uint64{makeOffsetAttacks([][2]int{{-1, -1}, {-1, 1}}),
+		makeOffsetAttacks([][2]int{{1, -1}, {1, 1}})}
+	rookRays   = makeRays(rookDirections)
+	bishopRays = makeRays(bishopDirections)
+)
+
+type ray struct {
+	tiles [64]uint64
+	// The nearest blocker is the lowest set bit in the rays towards the higher indexes and the highest one otherwise
+	isTowardsHigherIndex bool
+}
+
+type bitboards struct {
+	figures  [2][6]uint64
+	occupied [2]uint64
+}
+
+// The bitboards are built from the board on every query of the rules, which still takes a fraction of the time the
+// figure rules needed to scan the board for the attacks on the king (see BenchmarkIsKingCheck)
+func makeBitboards(board *Board) bitboards {
+	b := bitboards{}
+	for tile := 0; tile < 64; tile++ {
+		side, figureType := figureSideAndType(board[tile/8][tile%8])
+		if figureType != noFigureType {
+			b.set(side, figureType, tile)
+		}
+	}
+	return b
+}
+
+func (b *bitboards) set(side int, figureType int, tile int) {
+	bit := uint64(1) << tile
+	b.figures[side][figureType] |= bit
+	b.occupied[side] |= bit
+}
+
+func (b *bitboards) clear(side int, figureType int, tile int) {
+	bit := uint64(1) << tile
+	b.figures[side][figureType] &^= bit
+	b.occupied[side] &^= bit
+}
+
+// board returns the board with the figures of the bitboards
+func (b *bitboards) board() Board {
+	board := Board{}
+	for tile := 0; tile < 64; tile++ {
+		board[tile/8][tile%8] = Empty
+		for side := whiteSide; side <= blackSide; side++ {
+			if figureType := b.figureAt(side, tile); figureType != noFigureType {
+				board[tile/8][tile%8] = ColoredFigure(figureTypes[figureType], side == whiteSide)
+			}
+		}
+	}
+	return board
+}
+
+func (b *bitboards) all() uint64 {
+	return b.occupied[whiteSide] | b.occupied[blackSide]
+}
+
+func (b *bitboards) figureAt(side int, tile int) int {
+	bit := uint64(1) << tile
+	if b.occupied[side]&bit == 0 {
+		return noFigureType
+	}
+	for figureType := pawnType; figureType <= kingType; figureType++ {
+		if b.figures[side][figureType]&bit != 0 {
+			return figureType
+		}
+	}
+	return noFigureType
+}
+
+// isAttacked checks whether any figure of the side attacks the tile, by looking from the tile with each type of
+// the figure for the attacking figure of the same type
+func (b *bitboards) isAttacked(tile int, bySide int) bool {
+	figures := &b.figures[bySide]
+	if knightAttacks[tile]&figures[knightType] != 0 || kingAttacks[tile]&figures[kingType] != 0 ||
+		pawnAttacks[1-bySide][tile]&figures[pawnType] != 0 {
+		return true
+	}
+
+	occupied := b.all()
+	return slidingAttacks(tile, occupied, rookRays)&(figures[rookType]|figures[queenType]) != 0 ||
+		slidingAttacks(tile, occupied, bishopRays)&(figures[bishopType]|figures[queenType]) != 0
+}
+
+func (b *bitboards) isKingAttacked(side int) bool {
+	king := b.figures[side][kingType]
+	if king == 0 {
+		return false
+	}
+	return b.isAttacked(bits.TrailingZeros64(king), 1-side)
+}
+
+func slidingAttacks(tile int, occupied uint64, rays []ray) uint64 {
+	attacks := uint64(0)
+	for i := range rays {
+		r := &rays[i]
+		tiles := r.tiles[tile]
+		if blockers := tiles & occupied; blockers != 0 {
+			blocker := 63 - bits.LeadingZeros64(blockers)
+			if r.isTowardsHigherIndex {
+				blocker = bits.TrailingZeros64(blockers)
+			}
+			tiles &^= r.tiles[blocker]
+		}
+		attacks |= tiles
+	}
+	return attacks
+}
+
+func figureSideAndType(figure string) (int, int) {
+	if figure == "" || figure == Empty {
+		return whiteSide, noFigureType
+	}
+
+	side, char := whiteSide, figure[0]
+	if char >= 'a' && char <= 'z' {
+		side, char = blackSide, char-'a'+'A'
+	}
+	for figureType, f := range figureTypes {
+		if f[0] == char {
+			return side, figureType
+		}
+	}
+	return side, noFigureType
+}
+
+func sideOf(isWhite bool) int {
+	if isWhite {
+		return whiteSide
+	}
+	return blackSide
+}
+
+func makeOffsetAttacks(offsets [][2]int) [64]uint64 {
+	attacks := [64]uint64{}
+	for tile := 0; tile < 64; tile++ {
+		for _, o := range offsets {
+			row, col := tile/8+o[0], tile%8+o[1]
+			if row >= 0 && row <= 7 && col >= 0 && col <= 7 {
+				attacks[tile] |= uint64(1) << (row*8 + col)
+			}
+		}
+	}
+	return attacks
+}
+
+func makeRays(directions [][2]int) []ray {
+	rays := make([]ray, 0)
+	for _, d := range directions {
+		r := ray{isTowardsHigherIndex: d[0]*8+d[1] > 0}
+		for tile := 0; tile < 64; tile++ {
+			for row, col := tile/8+d[0], tile%8+d[1]; row >= 0 && row <= 7 && col >= 0 && col <= 7; row, col =
+				row+d[0], col+d[1] {
+				r.tiles[tile] |= uint64(1) << (row*8 + col)
+			}
+		}
+		rays = append(rays, r)
+	}
+	return rays
+}
@@ -0,0 +1,34 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"math/bits"
+	"testing"
+)
+
+func TestAttackTables(t *testing.T) {
+	utils.AssertTestCondition(t, 2, bits.OnesCount64(knightAttacks[0]), "Knight in the corner should attack 2 tiles")
+	utils.AssertTestCondition(t, 8, bits.OnesCount64(knightAttacks[27]), "Knight in the center should attack 8 tiles")
+	utils.AssertTestCondition(t, 3, bits.OnesCount64(kingAttacks[63]), "King in the corner should attack 3 tiles")
+	utils.AssertTestCondition(t, 1, bits.OnesCount64(pawnAttacks[whiteSide][48]), "Pawn on the edge should attack 1 tile")
+	utils.AssertTestCondition(t, uint64(1)<<41|uint64(1)<<43, pawnAttacks[whiteSide][50],
+		"White pawn on c2 should attack b3 and d3")
+}
+
+func TestStartingBitboards(t *testing.T) {
+	board, _ := parseTiles(MakeStartingBoard())
+	b := makeBitboards(board)
+	utils.AssertTestCondition(t, uint64(0xffff), b.occupied[blackSide], "Black figures should be on ranks 8 and 7")
+	utils.AssertTestCondition(t, uint64(0xffff)<<48, b.occupied[whiteSide], "White figures should be on ranks 2 and 1")
+	utils.AssertTestCondition(t, uint64(1)<<60, b.figures[whiteSide][kingType], "White king should be on e1")
+	utils.AssertTestCondition(t, queenType, b.figureAt(blackSide, 3), "Black queen should be on d8")
+	utils.AssertTestCondition(t, noFigureType, b.figureAt(whiteSide, 3), "White figure should not be on d8")
+}
+
+func TestSlidingAttacksBlocked(t *testing.T) {
+	p, _ := ParseFEN("4k3/8/8/8/R2p3r/8/8/4K3 w - - 0 1")
+	b := makeBitboards(&p.Board)
+	utils.AssertTestCondition(t, true, b.isAttacked(35, whiteSide), "Rook should attack the blocking pawn")
+	utils.AssertTestCondition(t, false, b.isAttacked(36, whiteSide), "Rook should not attack behind the pawn")
+	utils.AssertTestCondition(t, true, b.isAttacked(36, blackSide), "Black rook should attack the tile in front")
+}
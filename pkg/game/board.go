@@ -10,8 +10,11 @@ const (
 	CaptureSign          = "x"
 	KingCheckSign        = "+"
 	CheckmateSign        = "#"
+	EnPassantSign        = "e.p."
+	DropSign             = "@"
 	DrawOfferMove        = "="
 	DrawOfferRejectMove  = "!"
+	DrawClaimMove        = "=="
 	KingSideCastligMove  = "0-0"
 	QueenSideCastligMove = "0-0-0"
 )
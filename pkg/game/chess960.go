@@ -0,0 +1,107 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	Chess960PositionCount = 960
+	// StandardStartingPositionId is the Chess960 number of the standard starting position RNBQKBNR
+	StandardStartingPositionId = 518
+)
+
+// The placements of two knights on the five tiles left after the bishops and the queen are placed
+var chess960KnightPlacements = [10][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4},
+	{3, 4}}
+
+// RandomChess960PositionId returns the number of randomly chosen Chess960 starting position
+func RandomChess960PositionId() int {
+	return rand.Intn(Chess960PositionCount)
+}
+
+// Chess960BackRank returns the figures of the white back rank from the a to h file for the Chess960 starting position
+// number from 0 to 959, as defined by Scharnagl numbering scheme. The bishops are always on tiles of different colors
+// and the king is always between the rooks.
+func Chess960BackRank(id int) (string, error) {
+	if id < 0 || id >= Chess960PositionCount {
+		return "", errors.New(fmt.Sprintf("invalid Chess960 starting position: %d", id))
+	}
+
+	rank := [8]string{}
+	rank[2*(id%4)+1] = Bishop
+	id /= 4
+	rank[2*(id%4)] = Bishop
+	id /= 4
+
+	emptyColumns := func() []int {
+		columns := make([]int, 0)
+		for col, f := range rank {
+			if f == "" {
+				columns = append(columns, col)
+			}
+		}
+		return columns
+	}
+
+	rank[emptyColumns()[id%6]] = Queen
+	id /= 6
+
+	columns, knights := emptyColumns(), chess960KnightPlacements[id]
+	rank[columns[knights[0]]], rank[columns[knights[1]]] = Knight, Knight
+
+	columns = emptyColumns()
+	rank[columns[0]], rank[columns[1]], rank[columns[2]] = Rook, King, Rook
+
+	return strings.Join(rank[:], ""), nil
+}
+
+// MakeChess960Board creates the tiles of the board with the Chess960 starting position, where the black figures mirror
+// the white ones
+func MakeChess960Board(id int) (string, error) {
+	backRank, err := Chess960BackRank(id)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s", BlackFigure(backRank), strings.Repeat(BlackFigure(Pawn), 8),
+		strings.Repeat(Empty, 32), strings.Repeat(WhiteFigure(Pawn), 8), WhiteFigure(backRank)), nil
+}
+
+// Chess960StartingPosition creates the starting position with the castling files taken from the back rank
+func Chess960StartingPosition(id int) (*Position, error) {
+	tiles, err := MakeChess960Board(id)
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseTiles(tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	files := CastlingFiles{King: BoardColumnToFile(strings.Index(tiles[56:], King))}
+	files.QueenSideRook = BoardColumnToFile(strings.Index(tiles[56:], Rook))
+	files.KingSideRook = BoardColumnToFile(strings.LastIndex(tiles[56:], Rook))
+
+	p := Position{IsWhiteTurn: true, CastlingRights: AllCastlingRights, EnPassantTile: NoEnPassantTile,
+		FullMoveNumber: 1}
+	if files != StandardCastlingFiles {
+		p.CastlingFiles = files
+	}
+	p.settle(board, nil)
+	p.Hash = p.zobristHash()
+	return &p, nil
+}
+
+// ReplayChess960Game creates the game from the Chess960 starting position by replaying all moves played so far. The
+// standard starting position number replays the game of standard chess. The moves must be in normalized format.
+func ReplayChess960Game(id int, moves []string) (*Game, error) {
+	p, err := Chess960StartingPosition(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return replayGame(*p, moves)
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+func TestChess960BackRank(t *testing.T) {
+	ranks := map[int]string{0: "BBQNNRKR", 1: "BQNBNRKR", 518: "RNBQKBNR", 959: "RKRNNQBB"}
+	for id, expected := range ranks {
+		rank, err := Chess960BackRank(id)
+		utils.AssertTestCondition(t, nil, err, "Back rank should be created without error")
+		utils.AssertTestCondition(t, expected, rank, "Back rank should match the Chess960 starting position")
+	}
+
+	_, err := Chess960BackRank(Chess960PositionCount)
+	utils.AssertTestCondition(t, true, err != nil, "Back rank of invalid starting position should not be created")
+}
+
+func TestChess960BackRanksAreLegal(t *testing.T) {
+	ranks := make(map[string]bool)
+	for id := 0; id < Chess960PositionCount; id++ {
+		rank, _ := Chess960BackRank(id)
+		ranks[rank] = true
+
+		bishops := strings.Index(rank, Bishop) + strings.LastIndex(rank, Bishop)
+		king := strings.Index(rank, King)
+		if bishops%2 == 0 || king < strings.Index(rank, Rook) || king > strings.LastIndex(rank, Rook) {
+			t.Errorf("Back rank of starting position %d should be legal: %s", id, rank)
+		}
+	}
+	utils.AssertTestCondition(t, Chess960PositionCount, len(ranks), "All back ranks should be different")
+}
+
+func TestChess960StartingPositionFEN(t *testing.T) {
+	p, _ := Chess960StartingPosition(StandardStartingPositionId)
+	utils.AssertTestCondition(t, StartingFEN, p.FEN(), "Standard starting position should be exported to FEN")
+
+	p, _ = Chess960StartingPosition(959)
+	fen := "rkrnnqbb/pppppppp/8/8/8/8/PPPPPPPP/RKRNNQBB w KQkq - 0 1"
+	utils.AssertTestCondition(t, fen, p.FEN(), "Chess960 starting position should be exported to FEN")
+
+	parsed, _ := ParseFEN(fen)
+	utils.AssertTestCondition(t, CastlingFiles{King: "b", KingSideRook: "c", QueenSideRook: "a"},
+		parsed.CastlingFiles, "Castling files should be parsed from FEN")
+}
+
+func TestParseShredderFEN(t *testing.T) {
+	p, err := ParseFEN("b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w GE - 1 9")
+	utils.AssertTestCondition(t, nil, err, "Shredder-FEN should be parsed without error")
+	utils.AssertTestCondition(t, "b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w KQ - 1 9", p.FEN(),
+		"Castling rights should be exported to X-FEN")
+}
+
+func TestChess960KingSideCastling(t *testing.T) {
+	g, _ := ReplayChess960Game(200, []string{"Ng1h3", "ng8h6"})
+	move, _, err := g.MakeMove(KingSideCastligMove, true)
+	utils.AssertTestCondition(t, nil, err, "Chess960 castling move should be valid")
+	utils.AssertTestCondition(t, KingSideCastligMove, move, "Chess960 castling move should be played")
+	utils.AssertTestCondition(t, "QBNRBRK0", g.GetTiles()[56:], "King and rook should end on the standard tiles")
+	utils.AssertTestCondition(t, "kq", g.Position().CastlingRights, "White castling rights should be lost")
+
+	replayed, _ := ReplayChess960Game(200, []string{"Ng1h3", "ng8h6", KingSideCastligMove})
+	utils.AssertTestCondition(t, g.FEN(), replayed.FEN(), "Replayed Chess960 game should have the same position")
+}
+
+func TestChess960CastlingThroughFigure(t *testing.T) {
+	g, _ := ReplayChess960Game(200, []string{})
+	_, _, err := g.MakeMove(KingSideCastligMove, true)
+	utils.AssertTestCondition(t, true, err != nil, "Chess960 castling move should not pass through the figure")
+}
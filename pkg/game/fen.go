@@ -0,0 +1,273 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const StartingFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var (
+	fenCastlingRegex  = regexp.MustCompile("^(-|[KQA-H]{0,2}[kqa-h]{0,2})$")
+	fenEnPassantRegex = regexp.MustCompile("^(-|[a-h][36])$")
+)
+
+// FEN returns the current position of the game in Forsyth-Edwards Notation
+func (g *Game) FEN() string {
+	return g.Position().FEN()
+}
+
+// FEN godoc
+// Returns the position in Forsyth-Edwards Notation which consists of six fields separated by space: figure placement
+// from rank 8 to rank 1, side to move, castling rights, en passant tile, half move clock and full move number.
+// (e.g. rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1)
+//
+// The pocket in Crazyhouse is written in brackets after the figure placement (e.g. .../RNBQKB1R[Pn] w KQkq - 0 4)
+func (p *Position) FEN() string {
+	ranks := make([]string, 0, 8)
+	for i := 0; i < 8; i++ {
+		rank, empty := "", 0
+		for j := 0; j < 8; j++ {
+			if p.Board[i][j] == Empty {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				rank = fmt.Sprintf("%s%d", rank, empty)
+				empty = 0
+			}
+			rank += p.Board[i][j]
+		}
+		if empty > 0 {
+			rank = fmt.Sprintf("%s%d", rank, empty)
+		}
+		ranks = append(ranks, rank)
+	}
+
+	placement := strings.Join(ranks, "/")
+	if _, isCrazyhouse := p.Variant.(crazyhouse); isCrazyhouse {
+		placement += fmt.Sprintf("[%s]", p.Pocket)
+	}
+
+	side := "w"
+	if !p.IsWhiteTurn {
+		side = "b"
+	}
+
+	return fmt.Sprintf("%s %s %s %s %d %d", placement, side, p.fenCastlingRights(), p.EnPassantTile,
+		p.HalfMoveClock, p.FullMoveNumber)
+}
+
+// ParseFEN creates the position from Forsyth-Edwards Notation. The castling rights and en passant tile which are not
+// possible with the figures on the board are removed, so that equal positions always have equal notation.
+//
+// The castling rights of Chess960 positions can be written with KQkq for the outermost rooks (X-FEN) or with the files
+// of the rooks (Shredder-FEN), while the file of the king is found on the board.
+//
+// The position with the pocket in brackets after the figure placement is the position of Crazyhouse.
+func ParseFEN(fen string) (*Position, error) {
+	fields := strings.Fields(fen)
+	if len(fields) != 6 {
+		return nil, errors.New("FEN must contain 6 fields separated by space")
+	}
+
+	placement, pocket, isCrazyhouse := strings.Cut(fields[0], "[")
+	pocketFigures := [2][6]int{}
+	if isCrazyhouse {
+		if !strings.HasSuffix(pocket, "]") {
+			return nil, errors.New("FEN pocket must be closed with bracket")
+		}
+		var err error
+		pocketFigures, err = parsePocket(strings.TrimSuffix(pocket, "]"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	board, err := parseFENBoard(placement)
+	if err != nil {
+		return nil, err
+	}
+
+	if fields[1] != "w" && fields[1] != "b" {
+		return nil, errors.New(fmt.Sprintf("invalid side to move: %s", fields[1]))
+	}
+
+	if fields[2] == "" || !fenCastlingRegex.MatchString(fields[2]) {
+		return nil, errors.New(fmt.Sprintf("invalid castling rights: %s", fields[2]))
+	}
+
+	if !fenEnPassantRegex.MatchString(fields[3]) {
+		return nil, errors.New(fmt.Sprintf("invalid en passant tile: %s", fields[3]))
+	}
+
+	halfMoveClock, err := strconv.Atoi(fields[4])
+	if err != nil || halfMoveClock < 0 {
+		return nil, errors.New(fmt.Sprintf("invalid half move clock: %s", fields[4]))
+	}
+
+	fullMoveNumber, err := strconv.Atoi(fields[5])
+	if err != nil || fullMoveNumber < 1 {
+		return nil, errors.New(fmt.Sprintf("invalid full move number: %s", fields[5]))
+	}
+
+	castlingRights, castlingFiles := parseFENCastlingRights(fields[2], board)
+	p := Position{IsWhiteTurn: fields[1] == "w", CastlingRights: castlingRights, EnPassantTile: fields[3],
+		HalfMoveClock: halfMoveClock, FullMoveNumber: fullMoveNumber, CastlingFiles: castlingFiles}
+	if isCrazyhouse {
+		p.Variant, p.Pocket = FindVariant(CrazyhouseVariant), pocketString(pocketFigures)
+	}
+	p.settle(board, p.enPassantMove())
+	p.Hash = p.zobristHash()
+
+	return &p, nil
+}
+
+// MakeGameFromFEN creates the game from the position in Forsyth-Edwards Notation by replaying all moves played from
+// it. The moves must be in normalized format.
+func MakeGameFromFEN(fen string, moves []string) (*Game, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+
+	return replayGame(*p, moves)
+}
+
+// The castling right is written with the file of the rook when another rook is further away from the king on the
+// same side, as required by X-FEN
+func (p *Position) fenCastlingRights() string {
+	files := p.castlingFiles()
+	if files == StandardCastlingFiles || p.CastlingRights == NoCastlingRights {
+		return p.CastlingRights
+	}
+
+	kingCol := BoardFileToColumn(files.King)
+	rights := ""
+	for _, r := range p.CastlingRights {
+		right := string(r)
+		isWhite, isKingSide := right == strings.ToUpper(right), strings.ToUpper(right) == WhiteKingSideCastling
+		row, rookFile, direction := 0, files.QueenSideRook, -1
+		if isWhite {
+			row = 7
+		}
+		if isKingSide {
+			rookFile, direction = files.KingSideRook, 1
+		}
+
+		if outermostRookColumn(&p.Board, row, kingCol, direction, isWhite) != BoardFileToColumn(rookFile) {
+			right = ColoredFigure(rookFile, isWhite)
+		}
+		rights += right
+	}
+	return rights
+}
+
+func parseFENCastlingRights(field string, board *Board) (string, CastlingFiles) {
+	if field == NoCastlingRights {
+		return field, CastlingFiles{}
+	}
+
+	files, rights := StandardCastlingFiles, ""
+	for _, r := range field {
+		right := string(r)
+		isWhite := right == strings.ToUpper(right)
+		row, rank := 0, "8"
+		if isWhite {
+			row, rank = 7, "1"
+		}
+
+		_, kingCol := findFigureRowAndColumn(board, King, "", rank, isWhite)
+		if kingCol == -1 {
+			continue
+		}
+
+		rookCol := BoardFileToColumn(strings.ToLower(right))
+		switch strings.ToUpper(right) {
+		case WhiteKingSideCastling:
+			rookCol = outermostRookColumn(board, row, kingCol, 1, isWhite)
+		case WhiteQueenSideCastling:
+			rookCol = outermostRookColumn(board, row, kingCol, -1, isWhite)
+		}
+		if rookCol == -1 || rookCol == kingCol {
+			continue
+		}
+
+		files.King = BoardColumnToFile(kingCol)
+		if rookCol > kingCol {
+			files.KingSideRook = BoardColumnToFile(rookCol)
+			rights += ColoredFigure(WhiteKingSideCastling, isWhite)
+		} else {
+			files.QueenSideRook = BoardColumnToFile(rookCol)
+			rights += ColoredFigure(WhiteQueenSideCastling, isWhite)
+		}
+	}
+
+	if files == StandardCastlingFiles {
+		files = CastlingFiles{}
+	}
+	return filterCastlingRights(rights, func(r string) bool { return true }), files
+}
+
+// outermostRookColumn returns the column of the rook furthest away from the king in the direction on the row
+func outermostRookColumn(board *Board, row int, kingCol int, direction int, isWhite bool) int {
+	col := 0
+	if direction > 0 {
+		col = 7
+	}
+	for ; col != kingCol; col -= direction {
+		if board[row][col] == ColoredFigure(Rook, isWhite) {
+			return col
+		}
+	}
+	return -1
+}
+
+func parseFENBoard(placement string) (*Board, error) {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return nil, errors.New("FEN figure placement must contain 8 ranks")
+	}
+
+	board := Board{}
+	kings := map[string]int{}
+	for i, rank := range ranks {
+		j, lastEmpty := 0, false
+		for _, r := range rank {
+			char := string(r)
+			if r >= '1' && r <= '8' {
+				if lastEmpty || j+int(r-'0') > 8 {
+					return nil, errors.New(fmt.Sprintf("invalid rank %s in FEN figure placement", BoardRowToRank(i)))
+				}
+				for k := 0; k < int(r-'0'); k++ {
+					board[i][j] = Empty
+					j++
+				}
+				lastEmpty = true
+				continue
+			}
+			lastEmpty = false
+			if char == Empty || !IsValidFigure(char) || j >= 8 {
+				return nil, errors.New(fmt.Sprintf("invalid rank %s in FEN figure placement", BoardRowToRank(i)))
+			}
+			if IsFigureType(char, King) {
+				kings[char]++
+			}
+			board[i][j] = char
+			j++
+		}
+		if j != 8 {
+			return nil, errors.New(fmt.Sprintf("rank %s in FEN figure placement must contain 8 tiles",
+				BoardRowToRank(i)))
+		}
+	}
+
+	if kings[WhiteFigure(King)] != 1 || kings[BlackFigure(King)] != 1 {
+		return nil, errors.New("each player must have exactly one king")
+	}
+
+	return &board, nil
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+)
+
+func TestStartingPositionFEN(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	utils.AssertTestCondition(t, StartingFEN, g.FEN(), "Starting position should be exported to FEN")
+}
+
+func TestGameFEN(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{"Pe2e4", "pc7c5", "Ng1f3"})
+	utils.AssertTestCondition(t, "rnbqkbnr/pp1ppppp/8/2p5/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2", g.FEN(),
+		"Game position should be exported to FEN")
+}
+
+func TestGameFENFromStoredMoves(t *testing.T) {
+	moves := []string{"Pe2e4", "pc7c5", "Ng1f3"}
+	replayed, _ := ReplayGame(MakeStartingBoard(), moves)
+	g, err := MakeGame(replayed.GetTiles(), moves)
+	utils.AssertTestCondition(t, nil, err, "Game should be created without error")
+	utils.AssertTestCondition(t, replayed.FEN(), g.FEN(), "Game made from stored moves should have the same FEN")
+}
+
+func TestGameFENEnPassantTile(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{"Pe2e4", "pa7a6", "Pe4e5", "pd7d5"})
+	utils.AssertTestCondition(t, "rnbqkbnr/1pp1pppp/p7/3pP3/8/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 3", g.FEN(),
+		"En passant tile should be exported to FEN")
+}
+
+func TestGameFENCastlingRights(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{"Pe2e4", "pe7e5", "Ke1e2", "pa7a6", "Pa2a3", "ra8a7"})
+	utils.AssertTestCondition(t, "1nbqkbnr/rppp1ppp/p7/4p3/4P3/P7/1PPPKPPP/RNBQ1BNR w k - 1 4", g.FEN(),
+		"Lost castling rights should be exported to FEN")
+}
+
+func TestParseFEN(t *testing.T) {
+	fen := "r3k2r/8/8/8/8/8/8/R3K2R b Kq - 12 40"
+	p, err := ParseFEN(fen)
+	utils.AssertTestCondition(t, nil, err, "FEN should be parsed without error")
+	utils.AssertTestCondition(t, false, p.IsWhiteTurn, "Black player should be on turn")
+	utils.AssertTestCondition(t, "Kq", p.CastlingRights, "Castling rights should be parsed")
+	utils.AssertTestCondition(t, 12, p.HalfMoveClock, "Half move clock should be parsed")
+	utils.AssertTestCondition(t, 40, p.FullMoveNumber, "Full move number should be parsed")
+	utils.AssertTestCondition(t, fen, p.FEN(), "Parsed FEN should be exported unchanged")
+}
+
+func TestParseFENRemovesImpossibleRights(t *testing.T) {
+	p, err := ParseFEN("4k3/8/8/3pP3/8/8/8/4K2R w KQkq d6 0 1")
+	utils.AssertTestCondition(t, nil, err, "FEN should be parsed without error")
+	utils.AssertTestCondition(t, "K", p.CastlingRights, "Castling rights without figures in place should be removed")
+	utils.AssertTestCondition(t, "d6", p.EnPassantTile, "Possible en passant tile should be kept")
+
+	p, _ = ParseFEN("4k3/8/8/3p4/8/8/8/4K3 w - d6 0 1")
+	utils.AssertTestCondition(t, NoEnPassantTile, p.EnPassantTile, "Impossible en passant tile should be removed")
+}
+
+func TestParseInvalidFEN(t *testing.T) {
+	for _, fen := range []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/44/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/53/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/ppppxppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbq1bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkqk - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e4 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0",
+	} {
+		_, err := ParseFEN(fen)
+		utils.AssertTestCondition(t, true, err != nil, "Invalid FEN should not be parsed: "+fen)
+	}
+}
+
+func TestGameFromFEN(t *testing.T) {
+	g, err := MakeGameFromFEN("4k3/8/8/3pP3/8/8/8/4K2R w K d6 0 30", []string{})
+	utils.AssertTestCondition(t, nil, err, "Game should be created without error")
+
+	move, _, err := g.MakeMove("Pe5xd6", true)
+	utils.AssertTestCondition(t, nil, err, "En passant capture should be allowed from FEN position")
+	utils.AssertTestCondition(t, "Pe5xd6e.p.", move, "Move should be marked as en passant capture")
+	utils.AssertTestCondition(t, "4k3/8/3P4/8/8/8/8/4K2R b K - 0 30", g.FEN(), "Game position should be updated")
+}
+
+func TestGameFromFENCastlingRights(t *testing.T) {
+	g, _ := MakeGameFromFEN("r3k2r/8/8/8/8/8/8/R3K2R w Qk - 0 1", []string{})
+	_, _, err := g.MakeMove(KingSideCastligMove, true)
+	utils.AssertTestCondition(t, true, err != nil, "Castling without the right should not be allowed")
+
+	_, _, err = g.MakeMove(QueenSideCastligMove, true)
+	utils.AssertTestCondition(t, nil, err, "Castling with the right should be allowed")
+	utils.AssertTestCondition(t, "r3k2r/8/8/8/8/8/8/2KR3R b k - 1 1", g.FEN(), "Castling rights should be updated")
+}
+
+func TestGameFromFENSideToMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/8/8/8/8/4K3 b - - 0 1", []string{"Ke1e2"})
+	utils.AssertTestCondition(t, true, g == nil, "Game should not be replayed with the wrong side to move")
+
+	g, err := MakeGameFromFEN("4k3/8/8/8/8/8/8/4K3 b - - 0 1", []string{"ke8e7"})
+	utils.AssertTestCondition(t, nil, err, "Game should be replayed without error")
+	utils.AssertTestCondition(t, "8/4k3/8/8/8/8/8/4K3 w - - 1 2", g.FEN(), "Full move number should be increased")
+
+	_, _, err = g.MakeMove("ke7e6", false)
+	utils.AssertTestCondition(t, true, err != nil, "Move should not be allowed for player not on turn")
+}
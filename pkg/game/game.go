@@ -18,78 +18,297 @@ type Move struct {
 	DestinationRank     string
 	PromotedToFigure    string
 	IsCapture           bool
+	IsEnPassant         bool
 	IsKingSideCastling  bool
 	IsQueenSideCastling bool
 	IsKingCheck         bool
+	// The file of the castling rook in Chess960, which is empty for the standard castling
+	CastlingRookFile string
+	// The figure is dropped from the pocket in Crazyhouse, so it has no tile it moves from
+	IsDrop bool
 }
 
 type Game struct {
-	Board Board
-	Moves []Move
+	Board       Board
+	Moves       []Move
+	Positions   []Position
+	lastMoveSAN string
 }
 
-var moveRegex = regexp.MustCompile(
-	fmt.Sprintf("^(\\w)([a-h])?([1-8])?(%s)?([a-h])([1-8])(\\w)?([%s%s])?$", CaptureSign, KingCheckSign, CheckmateSign))
+type Outcome string
+
+const (
+	NoOutcome                   Outcome = ""
+	CheckmateOutcome            Outcome = "checkmate"
+	StalemateOutcome            Outcome = "stalemate"
+	InsufficientMaterialOutcome Outcome = "insufficient material"
+	ThreefoldRepetitionOutcome  Outcome = "threefold repetition"
+	FivefoldRepetitionOutcome   Outcome = "fivefold repetition"
+	FiftyMoveRuleOutcome        Outcome = "fifty-move rule"
+	SeventyFiveMoveRuleOutcome  Outcome = "seventy-five-move rule"
+)
+
+var (
+	moveRegex = regexp.MustCompile(
+		fmt.Sprintf("^(\\w)([a-h])?([1-8])?(%s)?([a-h])([1-8])(\\w)?(%s)?([%s%s])?$", CaptureSign,
+			regexp.QuoteMeta(EnPassantSign), KingCheckSign, CheckmateSign))
+	dropMoveRegex = regexp.MustCompile(fmt.Sprintf("^([PNBRQpnbrq])%s([a-h])([1-8])([%s%s])?$", DropSign,
+		KingCheckSign, CheckmateSign))
+)
 
 // MakeMove godoc
-// The move parameter represents the moving of a single figure on board and should be in following format:
-// (figure_char)(file)?(rank)?(capture_sign)?(file)(rank)(promoted_figure_char)(king_check_sign|checkmate_sign)?
+// The move parameter represents the moving of a single figure on board in Standard Algebraic Notation
+// (e.g. e4, Nf3, exd5, Nbd7, O-O, e8=Q+) or in the following legacy format:
+// (figure_char)(file)?(rank)?(capture_sign)?(file)(rank)(promoted_figure_char)(en_passant_sign)?(king_check_sign|checkmate_sign)?
 //
 // The x represents that this move captures opponents figure and + at the end that
 // this move is king check for opponent. (e.g. Qa3, Nxf3, Bxc4+, R3xa6+)
 //
+// The pawn capturing en passant is marked with e.p. after the destination tile (e.g. Pe5xd6e.p.)
+//
+// The move without the full position of the figure is resolved against all legal moves of the player, so it is
+// rejected if no figure or more than one figure can make it. The move which is valid in both formats is treated as
+// SAN, so the lowercase figure followed by capture is a pawn capture (e.g. bxc3).
+//
+// The figure from the pocket in Crazyhouse is dropped with @ followed by the destination tile (e.g. N@f3, p@e4)
+//
 // The move can also be a request for draw by containing only = (equals sign) or rejection of draw ! (exclamation mark)
 //
-// This function returns following values (normalized move, is game won, error)
-func (g *Game) MakeMove(move string, isWhite bool) (string, bool, error) {
+// The player on turn can claim a draw with == (double equals sign) when the current position has been repeated three
+// times or when the last 50 moves of both players were made without any capture or pawn move
+//
+// This function returns following values (normalized move, game outcome, error)
+func (g *Game) MakeMove(move string, isWhite bool) (string, Outcome, error) {
 	if slices.Contains([]string{DrawOfferMove, DrawOfferRejectMove}, move) {
-		return move, false, nil
+		return move, NoOutcome, nil
 	}
 
-	m, err := parseMove(move)
-	if err != nil {
-		return "", false, err
+	c := "white"
+	if !isWhite {
+		c = "black"
 	}
 
-	err = ValidateMove(&g.Board, m, isWhite, &g.Moves)
+	if isWhite != g.Position().IsWhiteTurn {
+		return "", NoOutcome, errors.New(fmt.Sprintf("It is not %s player's turn", c))
+	}
+
+	if move == DrawClaimMove {
+		outcome := g.ClaimableDraw()
+		if outcome == NoOutcome {
+			return "", NoOutcome, errors.New("Draw can be claimed only after threefold repetition or fifty moves " +
+				"without capture or pawn move")
+		}
+		return move, outcome, nil
+	}
+
+	m, err := parseSANMove(move)
 	if err != nil {
-		c := "white"
-		if !isWhite {
-			c = "black"
+		m, err = parseMove(move)
+	}
+	if err != nil {
+		return "", NoOutcome, err
+	}
+
+	if g.Position().Variant != nil {
+		err = g.resolveVariantMove(m)
+	} else {
+		g.setCastlingRookFile(m)
+		err = g.resolveFigurePosition(m, isWhite)
+		if err == nil {
+			err = g.validateCastlingRights(m, isWhite)
 		}
-		return "", false, errors.New(fmt.Sprintf(`Invalid move "%s" for %s player. Reason: %s`, move, c, err.Error()))
+		if err == nil {
+			err = ValidateMove(&g.Board, m, isWhite, g.moveHistory())
+		}
+	}
+	if err != nil {
+		return "", NoOutcome, errors.New(fmt.Sprintf(`Invalid move "%s" for %s player. Reason: %s`, move, c, err.Error()))
 	}
 
-	ExecuteMove(&g.Board, m, isWhite)
+	g.executeMove(m, isWhite)
 
 	moveStr := m.String()
-	isWin := IsGameWon(&g.Board, isWhite)
-	if isWin {
+	if outcome := g.Position().VariantOutcome(); outcome != NoOutcome {
+		return moveStr, outcome, nil
+	}
+
+	if g.Position().IsCheckmate() {
 		if m.IsKingCheck {
 			moveStr = strings.Replace(moveStr, KingCheckSign, CheckmateSign, 1)
 		} else {
-			moveStr = fmt.Sprintf("%s#", moveStr)
+			moveStr = fmt.Sprintf("%s%s", moveStr, CheckmateSign)
 		}
+		return moveStr, CheckmateOutcome, nil
+	}
+
+	if g.Position().IsStalemate() {
+		return moveStr, StalemateOutcome, nil
+	}
+
+	// The variants can be decided by their own win conditions even without the mating material
+	if g.Position().Variant == nil && IsInsufficientMaterial(&g.Board) {
+		return moveStr, InsufficientMaterialOutcome, nil
 	}
 
-	return m.String(), IsGameWon(&g.Board, isWhite), nil
+	if g.RepetitionCount() >= AutomaticRepetitionCount {
+		return moveStr, FivefoldRepetitionOutcome, nil
+	}
+
+	if g.Position().HalfMoveClock >= AutomaticHalfMoveCount {
+		return moveStr, SeventyFiveMoveRuleOutcome, nil
+	}
+
+	return moveStr, NoOutcome, nil
 }
 
-func (g *Game) GetTiles() string {
-	tiles := ""
+// ClaimableDraw returns the outcome of the draw which the player on turn can claim, or NoOutcome if there is none
+func (g *Game) ClaimableDraw() Outcome {
+	if g.RepetitionCount() >= ClaimRepetitionCount {
+		return ThreefoldRepetitionOutcome
+	}
+	if g.Position().HalfMoveClock >= ClaimHalfMoveCount {
+		return FiftyMoveRuleOutcome
+	}
+	return NoOutcome
+}
+
+// Position returns the current position of the game
+func (g *Game) Position() *Position {
+	return &g.Positions[len(g.Positions)-1]
+}
+
+// RepetitionCount returns how many times the current position has occurred in the game
+func (g *Game) RepetitionCount() int {
+	hash := g.Position().Hash
+	count := 0
+	for _, p := range g.Positions {
+		if p.Hash == hash {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *Game) executeMove(move *Move, isWhite bool) {
+	san := moveToSAN(&g.Board, move, isWhite)
+
+	if g.Position().Variant != nil {
+		if move.IsKingSideCastling || move.IsQueenSideCastling {
+			move.Figure = ColoredFigure(King, isWhite)
+		}
+		next := g.Position().playVariantMove(move)
+		g.Board = next.Board
+		g.Positions = append(g.Positions, next)
+	} else {
+		ExecuteMove(&g.Board, move, isWhite)
+		g.Positions = append(g.Positions, g.Position().next(&g.Board, move))
+	}
+	g.Moves = append(g.Moves, *move)
+
+	if g.Position().IsCheckmate() {
+		san += CheckmateSign
+	} else if move.IsKingCheck {
+		san += KingCheckSign
+	}
+	g.lastMoveSAN = san
+}
+
+// The position of the figure which is not fully specified in the move is found among the figures which can legally
+// make the move
+func (g *Game) resolveFigurePosition(move *Move, isWhite bool) error {
+	if move.IsKingSideCastling || move.IsQueenSideCastling || (move.FigureFile != "" && move.FigureRank != "") {
+		return nil
+	}
+
+	candidates := make([]Move, 0)
 	for i := 0; i < 8; i++ {
 		for j := 0; j < 8; j++ {
-			tiles = fmt.Sprintf("%s%s", tiles, g.Board[i][j])
+			if g.Board[i][j] != ColoredFigure(move.Figure, isWhite) ||
+				(move.FigureFile != "" && BoardColumnToFile(j) != move.FigureFile) ||
+				(move.FigureRank != "" && BoardRowToRank(i) != move.FigureRank) {
+				continue
+			}
+
+			candidate := *move
+			candidate.FigureFile, candidate.FigureRank = BoardColumnToFile(j), BoardRowToRank(i)
+			if ValidateMove(&g.Board, &candidate, isWhite, g.moveHistory()) != nil {
+				continue
+			}
+			candidates = append(candidates, candidate)
 		}
 	}
-	return tiles
+
+	if len(candidates) == 0 {
+		if IsFigureType(move.Figure, Pawn) && move.PromotedToFigure == "" && g.hasPromotionMove(move) {
+			return ErrPromotionRequired
+		}
+		return errors.New("no figure can make the move")
+	}
+	if len(candidates) > 1 {
+		return errors.New("cannot uniquely identify figure on board")
+	}
+
+	*move = candidates[0]
+	return nil
+}
+
+// The castling rook in Chess960 starts from the file given by the position of the game
+func (g *Game) setCastlingRookFile(move *Move) {
+	files := g.Position().castlingFiles()
+	if files == StandardCastlingFiles {
+		return
+	}
+
+	if move.IsKingSideCastling {
+		move.CastlingRookFile = files.KingSideRook
+	} else if move.IsQueenSideCastling {
+		move.CastlingRookFile = files.QueenSideRook
+	}
+}
+
+// CastlingRights returns the castling rights of the player in the current position of the game
+func (g *Game) CastlingRights(isWhite bool) CastlingRights {
+	return g.Position().PlayerCastlingRights(isWhite)
+}
+
+// The castling rights of the game started from a custom position are not visible from the history of moves
+func (g *Game) validateCastlingRights(move *Move, isWhite bool) error {
+	if !move.IsKingSideCastling && !move.IsQueenSideCastling {
+		return nil
+	}
+
+	rights := g.CastlingRights(isWhite)
+	if (move.IsKingSideCastling && !rights.KingSide) || (move.IsQueenSideCastling && !rights.QueenSide) {
+		return errors.New("castling right has been lost")
+	}
+	return nil
+}
+
+// The game started from a custom position has no history, so the pawn double move is recreated from the en passant
+// tile of the position to allow the capture
+func (g *Game) moveHistory() *[]Move {
+	if len(g.Moves) == 0 && len(g.Positions) == 1 {
+		if m := g.Position().enPassantMove(); m != nil {
+			return &[]Move{*m}
+		}
+	}
+	return &g.Moves
+}
+
+func (o Outcome) IsDraw() bool {
+	return slices.Contains([]Outcome{StalemateOutcome, InsufficientMaterialOutcome, ThreefoldRepetitionOutcome,
+		FivefoldRepetitionOutcome, FiftyMoveRuleOutcome, SeventyFiveMoveRuleOutcome}, o)
+}
+
+func (g *Game) GetTiles() string {
+	return boardTiles(&g.Board)
 }
 
 func (m *Move) String() string {
 	if m.IsKingSideCastling {
 		return KingSideCastligMove
 	}
-	if m.IsKingSideCastling {
+	if m.IsQueenSideCastling {
 		return QueenSideCastligMove
 	}
 
@@ -98,15 +317,26 @@ func (m *Move) String() string {
 		isCapture = CaptureSign
 	}
 
+	isEnPassant := ""
+	if m.IsEnPassant {
+		isEnPassant = EnPassantSign
+	}
+
 	isKingCheck := ""
 	if m.IsKingCheck {
 		isKingCheck = KingCheckSign
 	}
 
-	return fmt.Sprintf("%s%s%s%s%s%s%s%s", m.Figure, m.FigureFile, m.FigureRank, isCapture, m.DestinationFile,
-		m.DestinationRank, m.PromotedToFigure, isKingCheck)
+	if m.IsDrop {
+		return fmt.Sprintf("%s%s%s%s%s", m.Figure, DropSign, m.DestinationFile, m.DestinationRank, isKingCheck)
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s%s%s%s%s", m.Figure, m.FigureFile, m.FigureRank, isCapture, m.DestinationFile,
+		m.DestinationRank, m.PromotedToFigure, isEnPassant, isKingCheck)
 }
 
+// MakeGame creates the game from the current tiles on the board and the history of moves played so far. Since the
+// earlier positions are not known, only the current position is tracked for the repetition rules.
 func MakeGame(tiles string, moves []string) (*Game, error) {
 	board, err := parseTiles(tiles)
 	if err != nil {
@@ -114,24 +344,111 @@ func MakeGame(tiles string, moves []string) (*Game, error) {
 	}
 
 	movesList := make([]Move, 0)
+	position := Position{IsWhiteTurn: true, CastlingRights: AllCastlingRights, EnPassantTile: NoEnPassantTile,
+		FullMoveNumber: 1}
 	for _, m := range moves {
-		if m == DrawOfferMove || m == DrawOfferRejectMove {
+		if IsDrawMove(m) {
 			continue
 		}
 		move, e := parseMove(m)
 		if e != nil {
 			return nil, e
 		}
+		// The stored castling move has no figure, while the color of the figure is needed for castling rights
+		if move.IsKingSideCastling || move.IsQueenSideCastling {
+			move.Figure = ColoredFigure(King, position.IsWhiteTurn)
+		}
 		movesList = append(movesList, *move)
+		position = position.advance(move)
+	}
+
+	var lastMove *Move
+	if len(movesList) > 0 {
+		lastMove = &movesList[len(movesList)-1]
+	}
+	position.settle(board, lastMove)
+	position.Hash = position.zobristHash()
+
+	return &Game{Board: *board, Moves: movesList, Positions: []Position{position}}, nil
+}
+
+// ReplayGame creates the game from the starting tiles on the board by replaying all moves played so far, which
+// tracks every position reached in the game for the repetition rules. The moves must be in normalized format.
+func ReplayGame(tiles string, moves []string) (*Game, error) {
+	board, err := parseTiles(tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return replayGame(MakeStartingPosition(board), moves)
+}
+
+func replayGame(start Position, moves []string) (*Game, error) {
+	g := &Game{Board: start.Board, Moves: make([]Move, 0), Positions: []Position{start}}
+
+	isWhite := start.IsWhiteTurn
+	for _, m := range moves {
+		if IsDrawMove(m) {
+			continue
+		}
+		move, e := g.parseReplayMove(m, isWhite)
+		if e != nil {
+			return nil, e
+		}
+		g.executeMove(move, isWhite)
+		isWhite = !isWhite
+	}
+
+	return g, nil
+}
+
+// The moves are replayed without validation, so they must contain the position of the figure which is on turn
+func (g *Game) parseReplayMove(m string, isWhite bool) (*Move, error) {
+	move, err := parseMove(m)
+	if err != nil {
+		return nil, err
+	}
+	if move.IsKingSideCastling || move.IsQueenSideCastling {
+		g.setCastlingRookFile(move)
+		return move, nil
+	}
+	if move.IsDrop {
+		if !IsPlayersFigure(move.Figure, isWhite) {
+			return nil, errors.New(fmt.Sprintf("cannot replay drop of figure not on turn: %s", m))
+		}
+		return move, nil
+	}
+	if move.FigureFile == "" || move.FigureRank == "" {
+		return nil, errors.New(fmt.Sprintf("cannot replay move without figure position: %s", m))
+	}
+	if !IsPlayersFigure(move.Figure, isWhite) ||
+		g.Board[BoardRankToRow(move.FigureRank)][BoardFileToColumn(move.FigureFile)] != move.Figure {
+		return nil, errors.New(fmt.Sprintf("cannot replay move of figure not on turn: %s", m))
+	}
+	if IsFigureType(move.Figure, Pawn) &&
+		validatePromotion(BoardRankToRow(move.DestinationRank), isWhite, move.PromotedToFigure) != nil {
+		return nil, errors.New(fmt.Sprintf("cannot replay pawn move with invalid promotion: %s", m))
 	}
-	return &Game{*board, movesList}, nil
+	return move, nil
+}
+
+// IsDrawMove checks whether the move is the draw offer, its rejection or the draw claim, which are stored among the
+// moves of the game without changing the position on board
+func IsDrawMove(move string) bool {
+	return slices.Contains([]string{DrawOfferMove, DrawOfferRejectMove, DrawClaimMove}, move)
 }
 
 func parseMove(move string) (*Move, error) {
 	// 1 -> figure, 2 -> figure file, 3 -> figure rank, 4 -> capture char, 5 -> dest file, 6 -> dest rank,
-	// 7 -> promoted figure, 8 -> king check or endgame mark
+	// 7 -> promoted figure, 8 -> en passant mark, 9 -> king check or endgame mark
 	matches := moveRegex.FindStringSubmatch(move)
-	if len(matches) < 8 {
+	if len(matches) < 9 {
+		// 1 -> figure, 2 -> dest file, 3 -> dest rank, 4 -> king check or endgame mark
+		if drop := dropMoveRegex.FindStringSubmatch(move); drop != nil {
+			return &Move{Figure: drop[1], DestinationFile: drop[2], DestinationRank: drop[3], IsDrop: true,
+				IsKingCheck: drop[4] != ""}, nil
+		}
+
 		if move == KingSideCastligMove {
 			return &Move{IsKingSideCastling: true}, nil
 		}
@@ -163,17 +480,37 @@ func parseMove(move string) (*Move, error) {
 		promotedToFigure = matches[7]
 	}
 
+	isEnPassant := false
+	if matches[8] == EnPassantSign {
+		if !IsFigureType(matches[1], Pawn) {
+			return nil, errors.New(fmt.Sprintf("invalid figure for en passant capture: %s", matches[1]))
+		}
+		isEnPassant = true
+		isCapture = true
+	}
+
 	isKingCheck := false
-	if slices.Contains([]string{KingCheckSign, CheckmateSign}, matches[8]) {
+	if slices.Contains([]string{KingCheckSign, CheckmateSign}, matches[9]) {
 		isKingCheck = true
 	}
 
 	m := Move{Figure: matches[1], FigureFile: matches[2], FigureRank: matches[3], DestinationFile: matches[5],
-		DestinationRank: matches[6], PromotedToFigure: promotedToFigure, IsCapture: isCapture, IsKingCheck: isKingCheck}
+		DestinationRank: matches[6], PromotedToFigure: promotedToFigure, IsCapture: isCapture, IsEnPassant: isEnPassant,
+		IsKingCheck: isKingCheck}
 
 	return &m, nil
 }
 
+func boardTiles(board *Board) string {
+	tiles := ""
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			tiles = fmt.Sprintf("%s%s", tiles, board[i][j])
+		}
+	}
+	return tiles
+}
+
 func parseTiles(tiles string) (*Board, error) {
 	if len(tiles) != 64 {
 		return nil, errors.New("required number of tiles is 64")
@@ -54,3 +54,78 @@ func TestMakeGame(t *testing.T) {
 	_, err := MakeGame(MakeStartingBoard(), []string{"Pa2a4", "ng8f6", "Pc2c3"})
 	utils.AssertTestCondition(t, nil, err, "Game should be made without error")
 }
+
+func TestEnPassantMoveParser(t *testing.T) {
+	m, err := parseMove("Pe5xd6e.p.")
+	utils.AssertTestCondition(t, nil, err, "En passant move should be parsed without error")
+	utils.AssertTestCondition(t, true, m.IsEnPassant, "Move should be marked as en passant")
+	utils.AssertTestCondition(t, "Pe5xd6e.p.", m.String(), "En passant move should be formatted with its mark")
+}
+
+func TestEnPassantGameMove(t *testing.T) {
+	g, _ := MakeGame(MakeStartingBoard(), []string{})
+	for i, move := range []string{"Pe2e4", "Pa7a6", "Pe4e5", "Pd7d5"} {
+		_, _, err := g.MakeMove(move, i%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "Move should be played without error")
+	}
+
+	move, _, err := g.MakeMove("Pexd6", true)
+	utils.AssertTestCondition(t, nil, err, "En passant move should be played without error")
+	utils.AssertTestCondition(t, "Pe5xd6e.p.", move, "En passant move should be normalized")
+	utils.AssertTestCondition(t, "rnbqkbnr0pp0ppppp00P0000000000000000000000000000PPPP0PPPRNBQKBNR", g.GetTiles(),
+		"Captured pawn should be removed from the board")
+}
+
+func TestCheckmateGameOutcome(t *testing.T) {
+	g, _ := MakeGame(MakeStartingBoard(), []string{})
+	for i, move := range []string{"Pf2f3", "Pe7e5", "Pg2g4"} {
+		_, _, err := g.MakeMove(move, i%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "Move should be played without error")
+	}
+
+	move, outcome, err := g.MakeMove("Qd8h4", false)
+	utils.AssertTestCondition(t, nil, err, "Checkmate move should be played without error")
+	utils.AssertTestCondition(t, "qd8h4#", move, "Checkmate move should be marked")
+	utils.AssertTestCondition(t, CheckmateOutcome, outcome, "Game should end with checkmate")
+}
+
+func TestStalemateGameOutcome(t *testing.T) {
+	g, _ := MakeGame("k000000000000000000000000Q0000000000000000000000000000000000K000", []string{})
+
+	_, outcome, err := g.MakeMove("Qb5b6", true)
+	utils.AssertTestCondition(t, nil, err, "Stalemate move should be played without error")
+	utils.AssertTestCondition(t, StalemateOutcome, outcome, "Game should end with stalemate")
+	utils.AssertTestCondition(t, true, outcome.IsDraw(), "Stalemate should be a draw")
+}
+
+func TestPinnedFigureMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4r1k1/8/8/8/8/8/4B3/4K3 w - - 0 1", []string{})
+
+	_, _, err := g.MakeMove("Be2d3", true)
+	utils.AssertTestCondition(t, true, err != nil, "Pinned figure move should be rejected")
+
+	uci, err := g.UCIToMove("e2d3", true)
+	utils.AssertTestCondition(t, nil, err, "UCI move should be converted without error")
+	_, _, err = g.MakeMove(uci, true)
+	utils.AssertTestCondition(t, true, err != nil, "Pinned figure move in UCI format should be rejected")
+
+	_, _, err = g.MakeMove("Be2e3", true)
+	utils.AssertTestCondition(t, true, err != nil, "Pinned figure should not move off the line of the pin")
+	_, _, err = g.MakeMove("Kf2", true)
+	utils.AssertTestCondition(t, nil, err, "King move should be played without error")
+}
+
+func TestMoveWhileInCheck(t *testing.T) {
+	g, _ := MakeGameFromFEN("4r1k1/8/8/8/8/8/8/N3K3 w - - 0 1", []string{})
+
+	_, _, err := g.MakeMove("Na1b3", true)
+	utils.AssertTestCondition(t, true, err != nil, "Move leaving the king in check should be rejected")
+
+	uci, err := g.UCIToMove("a1b3", true)
+	utils.AssertTestCondition(t, nil, err, "UCI move should be converted without error")
+	_, _, err = g.MakeMove(uci, true)
+	utils.AssertTestCondition(t, true, err != nil, "Move leaving the king in check in UCI format should be rejected")
+
+	_, _, err = g.MakeMove("Kd2", true)
+	utils.AssertTestCondition(t, nil, err, "King move out of check should be played without error")
+}
@@ -0,0 +1,416 @@
+package game
+
+import (
+	"math/bits"
+	"strings"
+)
+
+const (
+	enPassantFlag = 1 << iota
+	kingSideCastlingFlag
+	queenSideCastlingFlag
+	doublePawnMoveFlag
+	dropFlag
+)
+
+var promotionTypes = []int{queenType, rookType, bishopType, knightType}
+
+var castlingRightBits = map[string]int{WhiteKingSideCastling: 1, WhiteQueenSideCastling: 2, BlackKingSideCastling: 4,
+	BlackQueenSideCastling: 8}
+
+type bitboardMove struct {
+	from      int
+	to        int
+	figure    int
+	captured  int
+	promotion int
+	flags     int
+}
+
+// The bitboard position is the compact copy of the position used for the move generation, which can be played
+// without touching the board of the game
+type bitboardPosition struct {
+	bitboards
+	side           int
+	castlingRights int
+	enPassant      int
+	// The columns of the king, king side rook and queen side rook at the start of the game
+	castlingColumns [3]int
+	rules           Variant
+	// The number of figures of each type in the pocket of both sides, which can be dropped on the board
+	pocket [2][6]int
+	// The tiles of the figures promoted from pawns
+	promoted uint64
+	// The number of checks given by both sides
+	checks [2]int
+}
+
+// LegalMoves godoc
+// Generates all legal moves of the player on turn in the position. The moves are fully specified: they contain the
+// colored figure, its tile, the destination tile, the promoted figure and all capture, en passant, castling and king
+// check flags, so their string value is the normalized move accepted by MakeMove and ReplayGame.
+//
+// The pawn reaching the last rank generates one move for each figure it can be promoted to.
+func LegalMoves(position *Position) []Move {
+	p := makeBitboardPosition(position)
+
+	moves := make([]Move, 0)
+	for _, m := range p.legalMoves(make([]bitboardMove, 0, 64)) {
+		moves = append(moves, p.toMove(&m))
+	}
+	return moves
+}
+
+// LegalMoves returns all legal moves of the player on turn in the current position of the game
+func (g *Game) LegalMoves() []Move {
+	return LegalMoves(g.Position())
+}
+
+// IsCheckmate checks whether the player on turn is in check and has no legal move
+func (p *Position) IsCheckmate() bool {
+	bp := makeBitboardPosition(p)
+	return bp.rules.isInCheck(&bp, bp.side) && bp.rules.outcome(&bp) == NoOutcome && len(bp.legalMoves(nil)) == 0
+}
+
+// IsStalemate checks whether the player on turn is not in check and has no legal move
+func (p *Position) IsStalemate() bool {
+	bp := makeBitboardPosition(p)
+	return !bp.rules.isInCheck(&bp, bp.side) && bp.rules.outcome(&bp) == NoOutcome && len(bp.legalMoves(nil)) == 0
+}
+
+func makeBitboardPosition(position *Position) bitboardPosition {
+	files := position.castlingFiles()
+	p := bitboardPosition{bitboards: makeBitboards(&position.Board), side: sideOf(position.IsWhiteTurn),
+		enPassant: -1, castlingColumns: [3]int{BoardFileToColumn(files.King), BoardFileToColumn(files.KingSideRook),
+			BoardFileToColumn(files.QueenSideRook)}, rules: position.Variant, promoted: position.promotedTiles,
+		checks: position.Checks}
+	if p.rules == nil {
+		p.rules = standardRules{}
+	}
+	// The pocket is validated when the position is created
+	p.pocket, _ = parsePocket(position.Pocket)
+	for right, bit := range castlingRightBits {
+		if strings.Contains(position.CastlingRights, right) {
+			p.castlingRights |= bit
+		}
+	}
+	if position.EnPassantTile != NoEnPassantTile {
+		p.enPassant = BoardRankToRow(position.EnPassantTile[1:])*8 + BoardFileToColumn(position.EnPassantTile[:1])
+	}
+	return p
+}
+
+// The pseudo legal moves are played and only those which are legal by the rules of the variant are kept, which are
+// the moves that do not leave the own king in check in standard chess. There are no moves in the position where the
+// variant has already decided the game.
+func (p *bitboardPosition) legalMoves(moves []bitboardMove) []bitboardMove {
+	if p.rules.outcome(p) != NoOutcome {
+		return moves
+	}
+
+	for _, m := range p.pseudoLegalMoves(make([]bitboardMove, 0, 64)) {
+		next := p.play(&m)
+		if p.rules.isLegal(p, &m, &next) {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+func (p *bitboardPosition) pseudoLegalMoves(moves []bitboardMove) []bitboardMove {
+	own, occupied := p.occupied[p.side], p.all()
+	// The king can never be captured, so the moves onto its tile are not generated
+	targets := ^own &^ p.figures[1-p.side][kingType]
+
+	for figureType := pawnType; figureType <= kingType; figureType++ {
+		for figures := p.figures[p.side][figureType]; figures != 0; figures &= figures - 1 {
+			from := bits.TrailingZeros64(figures)
+
+			var attacks uint64
+			switch figureType {
+			case pawnType:
+				moves = p.pawnMoves(moves, from, targets)
+				continue
+			case knightType:
+				attacks = knightAttacks[from]
+			case kingType:
+				attacks = kingAttacks[from]
+			case bishopType:
+				attacks = slidingAttacks(from, occupied, bishopRays)
+			case rookType:
+				attacks = slidingAttacks(from, occupied, rookRays)
+			case queenType:
+				attacks = slidingAttacks(from, occupied, rookRays) | slidingAttacks(from, occupied, bishopRays)
+			}
+
+			for dest := attacks & targets; dest != 0; dest &= dest - 1 {
+				to := bits.TrailingZeros64(dest)
+				moves = append(moves, bitboardMove{from: from, to: to, figure: figureType,
+					captured: p.figureAt(1-p.side, to), promotion: noFigureType})
+			}
+		}
+	}
+
+	return p.dropMoves(p.castlingMoves(moves))
+}
+
+func (p *bitboardPosition) pawnMoves(moves []bitboardMove, from int, targets uint64) []bitboardMove {
+	direction, startRow := 8, 1
+	if p.side == whiteSide {
+		direction, startRow = -8, 6
+	}
+
+	occupied := p.all()
+	if to := from + direction; to >= 0 && to < 64 && occupied&(uint64(1)<<to) == 0 {
+		moves = appendPawnMove(moves, bitboardMove{from: from, to: to, figure: pawnType, captured: noFigureType})
+		if double := to + direction; from/8 == startRow && occupied&(uint64(1)<<double) == 0 {
+			moves = append(moves, bitboardMove{from: from, to: double, figure: pawnType, captured: noFigureType,
+				promotion: noFigureType, flags: doublePawnMoveFlag})
+		}
+	}
+
+	for dest := pawnAttacks[p.side][from] & targets & p.occupied[1-p.side]; dest != 0; dest &= dest - 1 {
+		to := bits.TrailingZeros64(dest)
+		moves = appendPawnMove(moves, bitboardMove{from: from, to: to, figure: pawnType,
+			captured: p.figureAt(1-p.side, to)})
+	}
+
+	if p.enPassant >= 0 && pawnAttacks[p.side][from]&(uint64(1)<<p.enPassant) != 0 {
+		moves = append(moves, bitboardMove{from: from, to: p.enPassant, figure: pawnType, captured: pawnType,
+			promotion: noFigureType, flags: enPassantFlag})
+	}
+
+	return moves
+}
+
+func appendPawnMove(moves []bitboardMove, move bitboardMove) []bitboardMove {
+	if move.to/8 != 0 && move.to/8 != 7 {
+		move.promotion = noFigureType
+		return append(moves, move)
+	}
+
+	for _, figureType := range promotionTypes {
+		move.promotion = figureType
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// The figures in the pocket can be dropped on any empty tile, except the pawns on the first and the last rank
+func (p *bitboardPosition) dropMoves(moves []bitboardMove) []bitboardMove {
+	empty := ^p.all()
+	for figureType := pawnType; figureType < kingType; figureType++ {
+		if p.pocket[p.side][figureType] == 0 {
+			continue
+		}
+
+		tiles := empty
+		if figureType == pawnType {
+			tiles &^= backRanks
+		}
+		for ; tiles != 0; tiles &= tiles - 1 {
+			to := bits.TrailingZeros64(tiles)
+			moves = append(moves, bitboardMove{from: to, to: to, figure: figureType, captured: noFigureType,
+				promotion: noFigureType, flags: dropFlag})
+		}
+	}
+	return moves
+}
+
+// The castling is possible when the player has the right for it, the tiles which the king and the rook pass over or
+// land on are empty, and the king is not in check and does not pass through the attacked tile
+func (p *bitboardPosition) castlingMoves(moves []bitboardMove) []bitboardMove {
+	homeTile, kingSideRight, queenSideRight := 56, 1, 2
+	if p.side == blackSide {
+		homeTile, kingSideRight, queenSideRight = 0, 4, 8
+	}
+
+	kingCol := p.castlingColumns[0]
+	kingFrom := homeTile + kingCol
+	if p.castlingRights&(kingSideRight|queenSideRight) == 0 ||
+		p.figures[p.side][kingType]&(uint64(1)<<kingFrom) == 0 || p.isKingAttacked(p.side) {
+		return moves
+	}
+
+	candidates := []struct {
+		right       int
+		rookCol     int
+		kingDestCol int
+		rookDestCol int
+		flag        int
+	}{
+		{kingSideRight, p.castlingColumns[1], 6, 5, kingSideCastlingFlag},
+		{queenSideRight, p.castlingColumns[2], 2, 3, queenSideCastlingFlag},
+	}
+
+	occupied := p.all()
+	for _, c := range candidates {
+		rookFrom := homeTile + c.rookCol
+		if p.castlingRights&c.right == 0 || p.figures[p.side][rookType]&(uint64(1)<<rookFrom) == 0 {
+			continue
+		}
+
+		path := uint64(0)
+		for col := min(kingCol, c.kingDestCol, c.rookCol, c.rookDestCol); col <= max(kingCol, c.kingDestCol,
+			c.rookCol, c.rookDestCol); col++ {
+			path |= uint64(1) << (homeTile + col)
+		}
+		if occupied&path&^(uint64(1)<<kingFrom)&^(uint64(1)<<rookFrom) != 0 ||
+			p.isKingPathAttacked(homeTile, kingCol, c.kingDestCol) {
+			continue
+		}
+
+		moves = append(moves, bitboardMove{from: kingFrom, to: homeTile + c.kingDestCol, figure: kingType,
+			captured: noFigureType, promotion: noFigureType, flags: c.flag})
+	}
+
+	return moves
+}
+
+// The tile on which the king lands is checked when the move is played, since the rook can still block the attack
+func (p *bitboardPosition) isKingPathAttacked(homeTile int, kingCol int, kingDestCol int) bool {
+	step := 1
+	if kingDestCol < kingCol {
+		step = -1
+	}
+	for col := kingCol + step; col != kingDestCol && col-step != kingDestCol; col += step {
+		if p.isAttacked(homeTile+col, 1-p.side) {
+			return true
+		}
+	}
+	return false
+}
+
+// play returns the position after the move, where the en passant tile is set after every pawn double move
+func (p *bitboardPosition) play(move *bitboardMove) bitboardPosition {
+	n := *p
+	opponent := 1 - p.side
+
+	if move.flags&dropFlag != 0 {
+		n.set(p.side, move.figure, move.to)
+		n.pocket[p.side][move.figure]--
+	} else if move.flags&(kingSideCastlingFlag|queenSideCastlingFlag) != 0 {
+		homeTile, rookCol, rookDestCol := move.from/8*8, p.castlingColumns[1], 5
+		if move.flags&queenSideCastlingFlag != 0 {
+			rookCol, rookDestCol = p.castlingColumns[2], 3
+		}
+		// Both figures are removed first, since the king can land on the starting tile of the rook in Chess960
+		n.clear(p.side, rookType, homeTile+rookCol)
+		n.clear(p.side, kingType, move.from)
+		n.set(p.side, kingType, move.to)
+		n.set(p.side, rookType, homeTile+rookDestCol)
+	} else {
+		n.clear(p.side, move.figure, move.from)
+		if move.captured != noFigureType {
+			captureTile := move.to
+			if move.flags&enPassantFlag != 0 {
+				captureTile = move.from/8*8 + move.to%8
+			}
+			n.clear(opponent, move.captured, captureTile)
+		}
+		if move.promotion != noFigureType {
+			n.set(p.side, move.promotion, move.to)
+		} else {
+			n.set(p.side, move.figure, move.to)
+		}
+	}
+
+	n.castlingRights &^= p.castlingRightsLost(move.from) | p.castlingRightsLost(move.to)
+	if move.figure == kingType {
+		n.castlingRights &^= 3 << (2 * p.side)
+	}
+	n.enPassant = -1
+	if move.flags&doublePawnMoveFlag != 0 {
+		n.enPassant = (move.from + move.to) / 2
+	}
+	n.side = opponent
+	p.rules.afterMove(p, move, &n)
+
+	return n
+}
+
+// The castling right is lost when any figure moves from or to the starting tile of the rook
+func (p *bitboardPosition) castlingRightsLost(tile int) int {
+	switch tile {
+	case 56 + p.castlingColumns[1]:
+		return 1
+	case 56 + p.castlingColumns[2]:
+		return 2
+	case p.castlingColumns[1]:
+		return 4
+	case p.castlingColumns[2]:
+		return 8
+	}
+	return 0
+}
+
+func (p *bitboardPosition) toMove(move *bitboardMove) Move {
+	next := p.play(move)
+	isKingCheck := p.rules.isInCheck(&next, next.side)
+
+	if move.flags&dropFlag != 0 {
+		return Move{Figure: ColoredFigure(figureTypes[move.figure], p.side == whiteSide), IsDrop: true,
+			DestinationFile: BoardColumnToFile(move.to % 8), DestinationRank: BoardRowToRank(move.to / 8),
+			IsKingCheck: isKingCheck}
+	}
+
+	if move.flags&(kingSideCastlingFlag|queenSideCastlingFlag) != 0 {
+		m := Move{Figure: ColoredFigure(King, p.side == whiteSide), IsKingSideCastling: move.flags&kingSideCastlingFlag != 0,
+			IsQueenSideCastling: move.flags&queenSideCastlingFlag != 0, IsKingCheck: isKingCheck}
+		rookCol := p.castlingColumns[1]
+		if m.IsQueenSideCastling {
+			rookCol = p.castlingColumns[2]
+		}
+		if p.castlingColumns != [3]int{4, 7, 0} {
+			m.CastlingRookFile = BoardColumnToFile(rookCol)
+		}
+		return m
+	}
+
+	m := Move{Figure: ColoredFigure(figureTypes[move.figure], p.side == whiteSide),
+		FigureFile: BoardColumnToFile(move.from % 8), FigureRank: BoardRowToRank(move.from / 8),
+		DestinationFile: BoardColumnToFile(move.to % 8), DestinationRank: BoardRowToRank(move.to / 8),
+		IsCapture: move.captured != noFigureType, IsEnPassant: move.flags&enPassantFlag != 0,
+		IsKingCheck: isKingCheck}
+	if move.promotion != noFigureType {
+		m.PromotedToFigure = figureTypes[move.promotion]
+	}
+	return m
+}
+
+// fromMove returns the bitboard move of the player on turn for the fully specified move
+func (p *bitboardPosition) fromMove(move *Move) bitboardMove {
+	if move.IsKingSideCastling || move.IsQueenSideCastling {
+		homeTile, kingDestCol, flag := 56, 6, kingSideCastlingFlag
+		if p.side == blackSide {
+			homeTile = 0
+		}
+		if move.IsQueenSideCastling {
+			kingDestCol, flag = 2, queenSideCastlingFlag
+		}
+		return bitboardMove{from: homeTile + p.castlingColumns[0], to: homeTile + kingDestCol, figure: kingType,
+			captured: noFigureType, promotion: noFigureType, flags: flag}
+	}
+
+	_, figureType := figureSideAndType(move.Figure)
+	to := BoardRankToRow(move.DestinationRank)*8 + BoardFileToColumn(move.DestinationFile)
+	if move.IsDrop {
+		return bitboardMove{from: to, to: to, figure: figureType, captured: noFigureType, promotion: noFigureType,
+			flags: dropFlag}
+	}
+
+	from := BoardRankToRow(move.FigureRank)*8 + BoardFileToColumn(move.FigureFile)
+	m := bitboardMove{from: from, to: to, figure: figureType, captured: p.figureAt(1-p.side, to),
+		promotion: noFigureType}
+	if figureType == pawnType {
+		if from%8 != to%8 && m.captured == noFigureType {
+			m.captured, m.flags = pawnType, enPassantFlag
+		} else if from-to == 16 || to-from == 16 {
+			m.flags = doublePawnMoveFlag
+		}
+	}
+	if move.PromotedToFigure != "" {
+		_, m.promotion = figureSideAndType(move.PromotedToFigure)
+	}
+	return m
+}
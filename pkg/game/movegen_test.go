@@ -0,0 +1,99 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"slices"
+	"testing"
+)
+
+func TestStartingPositionLegalMoves(t *testing.T) {
+	p, _ := ParseFEN(StartingFEN)
+	moves := LegalMoves(p)
+	utils.AssertTestCondition(t, 20, len(moves), "Starting position should have 20 legal moves")
+}
+
+func TestLegalMovesCount(t *testing.T) {
+	positions := map[string]int{
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1": 48,
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1":                            14,
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1":     6,
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8":            44,
+	}
+	for fen, count := range positions {
+		p, err := ParseFEN(fen)
+		utils.AssertTestCondition(t, nil, err, "Position should be parsed without error")
+		utils.AssertTestCondition(t, count, len(LegalMoves(p)), "Legal moves count should match for: "+fen)
+	}
+}
+
+func TestLegalMovesArePlayable(t *testing.T) {
+	fen := "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+	g, _ := MakeGameFromFEN(fen, []string{})
+	for _, m := range g.LegalMoves() {
+		tg, _ := MakeGameFromFEN(fen, []string{})
+		move, _, err := tg.MakeMove(m.String(), true)
+		utils.AssertTestCondition(t, nil, err, "Legal move should be played without error: "+m.String())
+		utils.AssertTestCondition(t, m.String(), move, "Legal move should be normalized to itself")
+	}
+}
+
+func TestPromotionLegalMoves(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", []string{})
+	moves := make([]string, 0)
+	for _, m := range g.LegalMoves() {
+		moves = append(moves, m.String())
+	}
+	for _, m := range []string{"Pb7b8Q+", "Pb7b8R+", "Pb7b8B", "Pb7b8N"} {
+		utils.AssertTestCondition(t, true, slices.Contains(moves, m), "Promotion should be generated: "+m)
+	}
+	utils.AssertTestCondition(t, false, slices.Contains(moves, "Pb7b8"), "Pawn should not stay unpromoted")
+}
+
+func TestEnPassantLegalMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2", []string{})
+	moves := make([]string, 0)
+	for _, m := range g.LegalMoves() {
+		moves = append(moves, m.String())
+	}
+	utils.AssertTestCondition(t, true, slices.Contains(moves, "Pe5xd6e.p."), "En passant capture should be generated")
+}
+
+func TestPinnedEnPassantLegalMove(t *testing.T) {
+	// Both pawns leave the fifth rank after the capture, which exposes the king to the rook
+	g, _ := MakeGameFromFEN("4k3/8/8/K2pP2r/8/8/8/8 w - d6 0 2", []string{})
+	for _, m := range g.LegalMoves() {
+		utils.AssertTestCondition(t, false, m.IsEnPassant, "Pinned en passant capture should not be generated")
+	}
+}
+
+func TestCastlingLegalMoves(t *testing.T) {
+	// The b1 tile is attacked, but the king does not pass over it
+	g, _ := MakeGameFromFEN("1r2k3/8/8/8/8/8/8/R3K2R w KQ - 0 1", []string{})
+	moves := make([]string, 0)
+	for _, m := range g.LegalMoves() {
+		moves = append(moves, m.String())
+	}
+	utils.AssertTestCondition(t, true, slices.Contains(moves, KingSideCastligMove), "King side castling should be legal")
+	utils.AssertTestCondition(t, true, slices.Contains(moves, QueenSideCastligMove),
+		"Queen side castling should be legal")
+
+	g, _ = MakeGameFromFEN("2r1k3/8/8/8/8/8/8/R3K2R w KQ - 0 1", []string{})
+	for _, m := range g.LegalMoves() {
+		utils.AssertTestCondition(t, false, m.IsQueenSideCastling, "King should not castle over the attacked tile")
+	}
+}
+
+func TestCheckmateAndStalematePosition(t *testing.T) {
+	p, _ := ParseFEN("rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3")
+	utils.AssertTestCondition(t, true, p.IsCheckmate(), "Fool's mate should be checkmate")
+	utils.AssertTestCondition(t, false, p.IsStalemate(), "Checkmate should not be stalemate")
+
+	p, _ = ParseFEN("7k/5Q2/6K1/8/8/8/8/8 b - - 0 1")
+	utils.AssertTestCondition(t, true, p.IsStalemate(), "Position without legal moves should be stalemate")
+}
+
+func TestEnPassantEscapesCheck(t *testing.T) {
+	// The pawn giving check can be captured en passant, so it is not a checkmate
+	p, _ := ParseFEN("8/8/8/2k5/3Pp3/8/8/4K3 b - d3 0 1")
+	utils.AssertTestCondition(t, false, p.IsCheckmate(), "En passant capture should escape the check")
+}
@@ -0,0 +1,50 @@
+package game
+
+// Perft godoc
+// Counts the leaf nodes of the tree of legal moves from the position to the given depth. The counts of the standard
+// positions are published, so comparing them verifies the move generation including castling, en passant, promotion
+// and pins.
+func Perft(position *Position, depth int) int {
+	p := makeBitboardPosition(position)
+	return p.perft(depth)
+}
+
+// PerftFEN counts the leaf nodes of the tree of legal moves from the position in Forsyth-Edwards Notation
+func PerftFEN(fen string, depth int) (int, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return 0, err
+	}
+	return Perft(p, depth), nil
+}
+
+// PerftDivide returns the leaf nodes count after each legal move in the position, which is used to find the move
+// where the generation differs from the reference engine
+func PerftDivide(position *Position, depth int) map[string]int {
+	p := makeBitboardPosition(position)
+
+	divide := make(map[string]int)
+	for _, m := range p.legalMoves(make([]bitboardMove, 0, 64)) {
+		move, next := p.toMove(&m), p.play(&m)
+		divide[move.String()] = next.perft(depth - 1)
+	}
+	return divide
+}
+
+func (p *bitboardPosition) perft(depth int) int {
+	if depth <= 0 {
+		return 1
+	}
+
+	moves := p.legalMoves(make([]bitboardMove, 0, 64))
+	if depth == 1 {
+		return len(moves)
+	}
+
+	nodes := 0
+	for _, m := range moves {
+		next := p.play(&m)
+		nodes += next.perft(depth - 1)
+	}
+	return nodes
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"fmt"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+)
+
+type perftPosition struct {
+	name  string
+	fen   string
+	nodes []int
+}
+
+// The published node counts from https://www.chessprogramming.org/Perft_Results and Chess960 Perft Results, where the
+// deeper levels are tested only without the short flag
+var perftPositions = []perftPosition{
+	{"initial", StartingFEN, []int{20, 400, 8902, 197281, 4865609}},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039, 97862, 4085603}},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812, 43238}},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467, 422333}},
+	{"position 4 mirrored", "r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1",
+		[]int{6, 264, 9467}},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486, 62379, 2103487}},
+	{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		[]int{46, 2079, 89890, 3894594}},
+	{"chess960 position 1", "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9",
+		[]int{21, 528, 12189, 326672}},
+	{"chess960 position 2", "2nnrbkr/p1qppppp/8/1ppb4/6PP/3PP3/PPP2P2/BQNNRBKR w HEhe - 1 9",
+		[]int{21, 807, 18002, 667366}},
+	{"chess960 position 3", "b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w GE - 1 9",
+		[]int{20, 479, 10471, 273318}},
+}
+
+func TestPerft(t *testing.T) {
+	for _, p := range perftPositions {
+		for i, expected := range p.nodes {
+			depth := i + 1
+			if testing.Short() && depth > 2 {
+				break
+			}
+			nodes, err := PerftFEN(p.fen, depth)
+			utils.AssertTestCondition(t, nil, err, "Perft position should be parsed without error")
+			utils.AssertTestCondition(t, expected, nodes, fmt.Sprintf("Perft of %s at depth %d", p.name, depth))
+		}
+	}
+}
+
+func TestPerftDivide(t *testing.T) {
+	p, _ := ParseFEN(StartingFEN)
+	divide := PerftDivide(p, 2)
+	utils.AssertTestCondition(t, 20, len(divide), "Divide should contain all legal moves")
+	utils.AssertTestCondition(t, 20, divide["Pe2e4"], "Divide should count the replies to the move")
+}
+
+// Every generated move is played through MakeMove, which validates it with the rules of the board and updates the
+// hash incrementally, so both implementations must reach the same position
+func TestLegalMovesPlayedInGame(t *testing.T) {
+	for _, p := range perftPositions {
+		position, _ := ParseFEN(p.fen)
+		for _, m := range LegalMoves(position) {
+			expected := position.Play(&m)
+			g, _ := replayGame(*position, []string{})
+			_, _, err := g.MakeMove(m.String(), position.IsWhiteTurn)
+			utils.AssertTestCondition(t, nil, err,
+				fmt.Sprintf("Legal move %s in %s should be played", m.String(), p.name))
+			if err != nil {
+				continue
+			}
+			utils.AssertTestCondition(t, expected.FEN(), g.Position().FEN(),
+				fmt.Sprintf("Move %s in %s should reach the same position", m.String(), p.name))
+			utils.AssertTestCondition(t, expected.Hash, g.Position().Hash,
+				fmt.Sprintf("Move %s in %s should reach the same hash", m.String(), p.name))
+			utils.AssertTestCondition(t, g.Position().zobristHash(), g.Position().Hash,
+				fmt.Sprintf("Move %s in %s should update the hash of the position", m.String(), p.name))
+		}
+	}
+}
+
+// The fully specified moves of the figures on turn to all tiles are rejected unless they are generated as legal
+func TestIllegalMovesRejectedInGame(t *testing.T) {
+	for _, p := range perftPositions {
+		position, _ := ParseFEN(p.fen)
+		legal := make(map[string]bool)
+		for _, m := range LegalMoves(position) {
+			legal[m.FigureFile+m.FigureRank+m.DestinationFile+m.DestinationRank+m.PromotedToFigure] = true
+		}
+
+		for from := 0; from < 64; from++ {
+			figure := position.Board[from/8][from%8]
+			if figure == Empty || !IsPlayersFigure(figure, position.IsWhiteTurn) {
+				continue
+			}
+			for to := 0; to < 64; to++ {
+				tiles := BoardColumnToFile(from%8) + BoardRowToRank(from/8) + BoardColumnToFile(to%8) +
+					BoardRowToRank(to/8)
+				if IsFigureType(figure, Pawn) && (to/8 == 0 || to/8 == 7) {
+					tiles += Queen
+				}
+				if legal[tiles] {
+					continue
+				}
+
+				g, _ := replayGame(*position, []string{})
+				_, _, err := g.MakeMove(figure+tiles, position.IsWhiteTurn)
+				utils.AssertTestCondition(t, true, err != nil,
+					fmt.Sprintf("Illegal move %s in %s should be rejected", figure+tiles, p.name))
+			}
+		}
+	}
+}
+
+func BenchmarkLegalMoves(b *testing.B) {
+	p, _ := ParseFEN(perftPositions[1].fen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		LegalMoves(p)
+	}
+}
+
+func BenchmarkIsKingCheck(b *testing.B) {
+	p, _ := ParseFEN(perftPositions[1].fen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		IsKingCheck(&p.Board, true)
+	}
+}
+
+func BenchmarkWillKingBeInCheck(b *testing.B) {
+	p, _ := ParseFEN(perftPositions[1].fen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		willKingBeInCheck(&p.Board, 3, 4, 1, 3, true)
+	}
+}
+
+func BenchmarkMakeMove(b *testing.B) {
+	p, _ := ParseFEN(perftPositions[1].fen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g, _ := replayGame(*p, []string{})
+		_, _, _ = g.MakeMove("Nxd7", true)
+	}
+}
+
+func BenchmarkPerftInitial(b *testing.B) {
+	benchmarkPerft(b, StartingFEN, 3)
+}
+
+func BenchmarkPerftKiwipete(b *testing.B) {
+	benchmarkPerft(b, perftPositions[1].fen, 2)
+}
+
+func BenchmarkPerftPosition3(b *testing.B) {
+	benchmarkPerft(b, perftPositions[2].fen, 3)
+}
+
+func benchmarkPerft(b *testing.B, fen string, depth int) {
+	p, _ := ParseFEN(fen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Perft(p, depth)
+	}
+}
@@ -0,0 +1,283 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	WhiteWinsResult = "1-0"
+	BlackWinsResult = "0-1"
+	DrawResult      = "1/2-1/2"
+	UnknownResult   = "*"
+)
+
+const (
+	PGNEventTag   = "Event"
+	PGNSiteTag    = "Site"
+	PGNDateTag    = "Date"
+	PGNRoundTag   = "Round"
+	PGNWhiteTag   = "White"
+	PGNBlackTag   = "Black"
+	PGNResultTag  = "Result"
+	PGNSetUpTag   = "SetUp"
+	PGNFENTag     = "FEN"
+	PGNVariantTag = "Variant"
+)
+
+const PGNChess960Variant = "Chess960"
+
+const pgnLineLength = 79
+
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+// PGN godoc
+// The game in Portable Game Notation consists of tag pairs and the movetext of moves in Standard Algebraic Notation
+// followed by the game result. The tags should start with the Seven Tag Roster in order: Event, Site, Date, Round,
+// White, Black and Result.
+type PGN struct {
+	Tags   []PGNTag
+	Moves  []string
+	Result string
+}
+
+// MakePGN creates the game in Portable Game Notation from the moves in normalized format played from the position
+// in Forsyth-Edwards Notation. The result of the game is taken from the Result tag, and the rules by which the moves
+// are played from the Variant tag.
+func MakePGN(tags []PGNTag, fen string, moves []string) (*PGN, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+
+	pgn := PGN{Tags: tags, Result: UnknownResult}
+	for _, v := range variants {
+		if v.Title() == pgn.Tag(PGNVariantTag) {
+			p.Variant = v
+		}
+	}
+
+	pgn.Moves, err = p.SANMoves(moves)
+	if err != nil {
+		return nil, err
+	}
+	if result := pgn.Tag(PGNResultTag); result != "" {
+		pgn.Result = result
+	}
+	if fen != StartingFEN {
+		pgn.Tags = append(pgn.Tags, PGNTag{Name: PGNSetUpTag, Value: "1"}, PGNTag{Name: PGNFENTag, Value: fen})
+	}
+
+	return &pgn, nil
+}
+
+// Tag returns the value of the tag with the name, or empty string if there is none
+func (p *PGN) Tag(name string) string {
+	for _, t := range p.Tags {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+func (p *PGN) String() string {
+	var sb strings.Builder
+	for _, t := range p.Tags {
+		value := strings.ReplaceAll(strings.ReplaceAll(t.Value, `\`, `\\`), `"`, `\"`)
+		sb.WriteString(fmt.Sprintf("[%s \"%s\"]\n", t.Name, value))
+	}
+	sb.WriteString("\n")
+
+	moveNumber, isWhite := 1, true
+	if fen := p.Tag(PGNFENTag); fen != "" {
+		fields := strings.Fields(fen)
+		if len(fields) == 6 {
+			isWhite = fields[1] == "w"
+			if n, err := strconv.Atoi(fields[5]); err == nil {
+				moveNumber = n
+			}
+		}
+	}
+
+	tokens := make([]string, 0)
+	for i, m := range p.Moves {
+		if isWhite {
+			tokens = append(tokens, fmt.Sprintf("%d.", moveNumber))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d...", moveNumber))
+		}
+		tokens = append(tokens, m)
+		if !isWhite {
+			moveNumber++
+		}
+		isWhite = !isWhite
+	}
+	tokens = append(tokens, p.Result)
+
+	// The movetext lines are wrapped to stay within the maximum length recommended by the standard
+	line := ""
+	for _, t := range tokens {
+		if line != "" && len(line)+len(t)+1 > pgnLineLength {
+			sb.WriteString(line + "\n")
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += t
+	}
+	sb.WriteString(line + "\n")
+
+	return sb.String()
+}
+
+var (
+	pgnTagRegex        = regexp.MustCompile(`^\[\s*(\w+)\s+"((?:[^"\\]|\\.)*)"\s*]`)
+	pgnMoveNumberRegex = regexp.MustCompile(`^\d+\.+`)
+)
+
+// ParsePGN godoc
+// Parses all games from the text in Portable Game Notation. The comments, numeric annotation glyphs, move suffix
+// annotations, escaped lines and recursive variations are skipped, so only the tags and the main line moves in
+// Standard Algebraic Notation are kept. Every game must end with the game termination marker (1-0, 0-1, 1/2-1/2 or *).
+func ParsePGN(text string) ([]PGN, error) {
+	games := make([]PGN, 0)
+	current := PGN{Tags: make([]PGNTag, 0), Moves: make([]string, 0)}
+	variationDepth := 0
+
+	for i := 0; i < len(text); {
+		char := text[i]
+		switch {
+		case char == ' ' || char == '\t' || char == '\r' || char == '\n':
+			i++
+		case char == '%' && (i == 0 || text[i-1] == '\n'), char == ';':
+			i = skipPGNUntil(text, i, "\n")
+		case char == '{':
+			end := skipPGNUntil(text, i, "}")
+			if end > len(text) {
+				return nil, errors.New("unterminated comment in PGN")
+			}
+			i = end
+		case char == '(':
+			variationDepth++
+			i++
+		case char == ')':
+			variationDepth--
+			if variationDepth < 0 {
+				return nil, errors.New("unexpected end of variation in PGN")
+			}
+			i++
+		case char == '[' && variationDepth == 0:
+			if len(current.Moves) > 0 {
+				return nil, errors.New(fmt.Sprintf("missing game termination marker in game %d", len(games)+1))
+			}
+			matches := pgnTagRegex.FindStringSubmatch(text[i:])
+			if matches == nil {
+				return nil, errors.New(fmt.Sprintf("invalid tag pair in game %d", len(games)+1))
+			}
+			value := strings.ReplaceAll(strings.ReplaceAll(matches[2], `\"`, `"`), `\\`, `\`)
+			current.Tags = append(current.Tags, PGNTag{Name: matches[1], Value: value})
+			i += len(matches[0])
+		default:
+			end := i
+			for end < len(text) && !strings.ContainsRune(" \t\r\n{}();[", rune(text[end])) {
+				end++
+			}
+			if end == i {
+				return nil, errors.New(fmt.Sprintf("unexpected character %q in PGN", char))
+			}
+			token := text[i:end]
+			i = end
+
+			if variationDepth > 0 || strings.HasPrefix(token, "$") {
+				continue
+			}
+			if slices.Contains([]string{WhiteWinsResult, BlackWinsResult, DrawResult, UnknownResult}, token) {
+				current.Result = token
+				games = append(games, current)
+				current = PGN{Tags: make([]PGNTag, 0), Moves: make([]string, 0)}
+				continue
+			}
+			token = strings.TrimRight(pgnMoveNumberRegex.ReplaceAllString(token, ""), "!?")
+			if token != "" {
+				current.Moves = append(current.Moves, token)
+			}
+		}
+	}
+
+	if variationDepth > 0 {
+		return nil, errors.New("unterminated variation in PGN")
+	}
+	if len(current.Tags) > 0 || len(current.Moves) > 0 {
+		return nil, errors.New(fmt.Sprintf("missing game termination marker in game %d", len(games)+1))
+	}
+
+	return games, nil
+}
+
+// Replay godoc
+// Plays all moves of the game through the rules engine starting from the position in the FEN tag, or from the
+// standard starting position if there is none. Returns the game, its moves in normalized format and the outcome
+// detected by the engine, which must agree with the game result.
+func (p *PGN) Replay() (*Game, []string, Outcome, error) {
+	fen := StartingFEN
+	if p.Tag(PGNFENTag) != "" {
+		fen = p.Tag(PGNFENTag)
+	}
+
+	g, err := MakeGameFromFEN(fen, []string{})
+	if err != nil {
+		return nil, nil, NoOutcome, err
+	}
+
+	moves := make([]string, 0)
+	outcome := NoOutcome
+	for i, san := range p.Moves {
+		isWhite := g.Position().IsWhiteTurn
+		if outcome != NoOutcome {
+			return nil, nil, NoOutcome, errors.New(fmt.Sprintf("move %s played after the game has ended by %s",
+				san, outcome))
+		}
+
+		move, e := g.SANToMove(san, isWhite)
+		if e == nil {
+			move, outcome, e = g.MakeMove(move, isWhite)
+		}
+		if e != nil {
+			return nil, nil, NoOutcome, errors.New(fmt.Sprintf("invalid move %d (%s): %s", i+1, san, e.Error()))
+		}
+		moves = append(moves, move)
+	}
+
+	expectedResult := ""
+	if outcome == CheckmateOutcome {
+		expectedResult = BlackWinsResult
+		if !g.Position().IsWhiteTurn {
+			expectedResult = WhiteWinsResult
+		}
+	} else if outcome.IsDraw() {
+		expectedResult = DrawResult
+	}
+	if expectedResult != "" && expectedResult != p.Result {
+		return nil, nil, NoOutcome, errors.New(fmt.Sprintf("game result %s does not match the %s", p.Result,
+			outcome))
+	}
+
+	return g, moves, outcome, nil
+}
+
+func skipPGNUntil(text string, start int, end string) int {
+	index := strings.Index(text[start:], end)
+	if index == -1 {
+		return len(text) + 1
+	}
+	return start + index + 1
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+func TestPGNExport(t *testing.T) {
+	tags := []PGNTag{{PGNEventTag, "Casual game"}, {PGNSiteTag, "chess-cli"}, {PGNDateTag, "2026.10.17"},
+		{PGNRoundTag, "-"}, {PGNWhiteTag, "alice"}, {PGNBlackTag, `bob "the rook"`}, {PGNResultTag, BlackWinsResult}}
+	pgn, err := MakePGN(tags, StartingFEN, []string{"Pf2f3", "pe7e5", "Pg2g4", "qd8h4#"})
+	utils.AssertTestCondition(t, nil, err, "PGN should be created without error")
+
+	expected := `[Event "Casual game"]
+[Site "chess-cli"]
+[Date "2026.10.17"]
+[Round "-"]
+[White "alice"]
+[Black "bob \"the rook\""]
+[Result "0-1"]
+
+1. f3 e5 2. g4 Qh4# 0-1
+`
+	utils.AssertTestCondition(t, expected, pgn.String(), "PGN should be exported")
+}
+
+func TestPGNExportFromPosition(t *testing.T) {
+	fen := "4k3/8/8/8/8/8/4p3/4K3 b - - 0 40"
+	pgn, err := MakePGN([]PGNTag{{PGNResultTag, UnknownResult}}, fen, []string{"ke8d7", "Ke1xe2"})
+	utils.AssertTestCondition(t, nil, err, "PGN should be created without error")
+
+	expected := `[Result "*"]
+[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/4p3/4K3 b - - 0 40"]
+
+40... Kd7 41. Kxe2 *
+`
+	utils.AssertTestCondition(t, expected, pgn.String(), "PGN should be exported with the starting position")
+}
+
+func TestPGNMovetextWrapping(t *testing.T) {
+	moves := make([]string, 0)
+	for i := 0; i < 10; i++ {
+		moves = append(moves, "Ng1f3", "ng8f6", "Nf3g1", "nf6g8")
+	}
+
+	pgn, _ := MakePGN([]PGNTag{}, StartingFEN, moves)
+	for _, line := range strings.Split(pgn.String(), "\n") {
+		utils.AssertTestCondition(t, true, len(line) <= pgnLineLength, "Movetext line should not be too long: "+line)
+	}
+}
+
+const operaGamePGN = `[Event "Paris"]
+[Site "Paris FRA"]
+[Date "1858.??.??"]
+[Round "?"]
+[White "Paul Morphy"]
+[Black "Duke Karl / Count Isouard"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 {This is a weak move already.} 4. dxe5 Bxf3 5. Qxf3
+dxe5 6. Bc4 Nf6 7. Qb3 Qe7 8. Nc3 c6 9. Bg5 b5?! (9... Qb4+ 10. Qxb4 Bxb4) 10. Nxb5!
+cxb5 11. Bxb5+ Nbd7 12. O-O-O Rd8 13. Rxd7 $1 Rxd7 14. Rd1 Qe6 15. Bxd7+ Nxd7
+16. Qb8+ ; the queen sacrifice
+Nxb8 17. Rd8# 1-0
+`
+
+func TestPGNParser(t *testing.T) {
+	games, err := ParsePGN(operaGamePGN + "\n" + `[Event "Second"]
+[Result "1/2-1/2"]
+
+%this line is escaped
+1.e4 (1.d4 d5 (1...Nf6)) 1...e5 {short draw} 1/2-1/2
+
+1. d4 *`)
+	utils.AssertTestCondition(t, nil, err, "PGN should be parsed without error")
+	utils.AssertTestCondition(t, 3, len(games), "All games should be parsed")
+
+	utils.AssertTestCondition(t, "Duke Karl / Count Isouard", games[0].Tag(PGNBlackTag), "Tags should be parsed")
+	utils.AssertTestCondition(t, 33, len(games[0].Moves), "Main line moves should be parsed")
+	utils.AssertTestCondition(t, "b5", games[0].Moves[17], "Move suffix annotations should be removed")
+	utils.AssertTestCondition(t, "Nxb5", games[0].Moves[18], "Variations should be skipped")
+	utils.AssertTestCondition(t, WhiteWinsResult, games[0].Result, "Result should be parsed")
+
+	utils.AssertTestCondition(t, "e4 e5", strings.Join(games[1].Moves, " "), "Nested variations should be skipped")
+	utils.AssertTestCondition(t, DrawResult, games[1].Result, "Result should be parsed")
+
+	utils.AssertTestCondition(t, 0, len(games[2].Tags), "Game without tags should be parsed")
+	utils.AssertTestCondition(t, UnknownResult, games[2].Result, "Result should be parsed")
+}
+
+func TestInvalidPGNParser(t *testing.T) {
+	for _, pgn := range []string{
+		"1. e4 e5",
+		"[Event \"Missing result\"]",
+		"1. e4 {unterminated comment",
+		"1. e4 (1. d4 1-0",
+		"1. e4 e5) 1-0",
+		"[Event Unquoted] 1-0",
+		"1. e4 [Event \"Tag in movetext\"] 1-0",
+	} {
+		_, err := ParsePGN(pgn)
+		utils.AssertTestCondition(t, true, err != nil, "Invalid PGN should not be parsed: "+pgn)
+	}
+}
+
+func TestPGNReplay(t *testing.T) {
+	games, _ := ParsePGN(operaGamePGN)
+	g, moves, outcome, err := games[0].Replay()
+	utils.AssertTestCondition(t, nil, err, "Game should be replayed without error")
+	utils.AssertTestCondition(t, CheckmateOutcome, outcome, "Game should end with checkmate")
+	utils.AssertTestCondition(t, "Pe2e4", moves[0], "Moves should be normalized")
+	utils.AssertTestCondition(t, "nb8d7", moves[21], "Disambiguated move should be normalized")
+	utils.AssertTestCondition(t, QueenSideCastligMove, moves[22], "Castling move should be normalized")
+	utils.AssertTestCondition(t, "Rd1d8#", moves[32], "Checkmate move should be normalized")
+	utils.AssertTestCondition(t, "1n1Rkb1r/p4ppp/4q3/4p1B1/4P3/8/PPP2PPP/2K5 b k - 1 17", g.FEN(),
+		"Game should end in the final position")
+
+	exported, _ := MakePGN(games[0].Tags, StartingFEN, moves)
+	reparsed, _ := ParsePGN(exported.String())
+	utils.AssertTestCondition(t, strings.Join(games[0].Moves, " "), strings.Join(reparsed[0].Moves, " "),
+		"Exported game should be parsed to the same moves")
+}
+
+func TestPGNReplayWrongResult(t *testing.T) {
+	games, _ := ParsePGN("1. f3 e5 2. g4 Qh4# 1-0")
+	_, _, _, err := games[0].Replay()
+	utils.AssertTestCondition(t, true, err != nil, "Game with the result not matching checkmate should be rejected")
+}
+
+func TestPGNReplayIllegalMove(t *testing.T) {
+	games, _ := ParsePGN("1. e4 e5 2. Ke3 *")
+	_, _, _, err := games[0].Replay()
+	utils.AssertTestCondition(t, true, err != nil, "Game with illegal move should be rejected")
+}
+
+func TestPGNReplayFromPosition(t *testing.T) {
+	games, _ := ParsePGN(`[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/4p3/4K3 b - - 0 40"]
+
+40... Kd7 41. Kxe2 1/2-1/2`)
+	_, moves, outcome, err := games[0].Replay()
+	utils.AssertTestCondition(t, nil, err, "Game should be replayed without error")
+	utils.AssertTestCondition(t, "ke8d7 Ke1xe2", strings.Join(moves, " "), "Moves should be normalized")
+	utils.AssertTestCondition(t, InsufficientMaterialOutcome, outcome, "Game should end with insufficient material")
+}
@@ -0,0 +1,235 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	WhiteKingSideCastling  = "K"
+	WhiteQueenSideCastling = "Q"
+	BlackKingSideCastling  = "k"
+	BlackQueenSideCastling = "q"
+	AllCastlingRights      = "KQkq"
+	NoCastlingRights       = "-"
+	NoEnPassantTile        = "-"
+)
+
+const (
+	ClaimRepetitionCount     = 3
+	AutomaticRepetitionCount = 5
+	ClaimHalfMoveCount       = 100
+	AutomaticHalfMoveCount   = 150
+)
+
+// Position godoc
+// The position identifies the state of the game: figures on the board, the side to move, castling rights of both
+// players, the tile on which en passant capture is possible and the move counters. Only the first four are relevant
+// for the repetition rules, together with the pocket and the checks given in the variants which have them.
+type Position struct {
+	Board          Board
+	IsWhiteTurn    bool
+	CastlingRights string
+	EnPassantTile  string
+	HalfMoveClock  int
+	FullMoveNumber int
+	// The zero value is used for the standard files
+	CastlingFiles CastlingFiles
+	// The rules of the variant played, which is nil for standard chess and Chess960
+	Variant Variant
+	// The figures captured in Crazyhouse which can be dropped on the board (e.g. QNPpp)
+	Pocket string
+	// The number of checks given by the white and the black player in Three-check
+	Checks [2]int
+	// The tiles of the figures promoted from pawns in Crazyhouse, which turn back into pawns when captured
+	promotedTiles uint64
+	// The Zobrist hash which is equal for all positions considered the same by the repetition rules
+	Hash uint64
+}
+
+// CastlingFiles are the files on which the king and the rooks start the game, which differ from the standard ones only
+// in Chess960, while the king and the rook always end on the same tiles after castling
+type CastlingFiles struct {
+	King          string
+	KingSideRook  string
+	QueenSideRook string
+}
+
+var StandardCastlingFiles = CastlingFiles{King: "e", KingSideRook: "h", QueenSideRook: "a"}
+
+// CastlingRights of the single player, which are lost when the king or the rook moves, or when the rook is captured
+// on its home tile
+type CastlingRights struct {
+	KingSide  bool
+	QueenSide bool
+}
+
+// PlayerCastlingRights returns the castling rights of the player in the position
+func (p *Position) PlayerCastlingRights(isWhite bool) CastlingRights {
+	return playerCastlingRights(p.CastlingRights, isWhite)
+}
+
+func (p *Position) castlingFiles() CastlingFiles {
+	if p.CastlingFiles == (CastlingFiles{}) {
+		return StandardCastlingFiles
+	}
+	return p.CastlingFiles
+}
+
+// MakeStartingPosition creates the position with white player on turn from the board, where all castling rights are
+// kept for which the king and rook are still on their starting tiles
+func MakeStartingPosition(board *Board) Position {
+	p := Position{IsWhiteTurn: true, CastlingRights: AllCastlingRights, EnPassantTile: NoEnPassantTile,
+		FullMoveNumber: 1}
+	p.settle(board, nil)
+	p.Hash = p.zobristHash()
+	return p
+}
+
+// next returns the position reached after the move has been played, where the board contains the figures after it
+func (p *Position) next(board *Board, move *Move) Position {
+	n := p.advance(move)
+	n.settle(board, move)
+	n.Hash = p.nextHash(&n, p.moveTiles(move))
+	return n
+}
+
+// Play returns the position reached after the legal move of the player on turn is played on the copy of the board
+func (p *Position) Play(move *Move) Position {
+	if p.Variant != nil {
+		return p.playVariantMove(move)
+	}
+
+	board, m := p.Board, *move
+	ExecuteMove(&board, &m, p.IsWhiteTurn)
+	return p.next(&board, &m)
+}
+
+// advance updates the side to move, move counters and castling rights lost by the move without knowing the board
+func (p *Position) advance(move *Move) Position {
+	n := *p
+	n.IsWhiteTurn = !p.IsWhiteTurn
+	if !p.IsWhiteTurn {
+		n.FullMoveNumber++
+	}
+	if move.IsCapture || IsFigureType(move.Figure, Pawn) {
+		n.HalfMoveClock = 0
+	} else {
+		n.HalfMoveClock++
+	}
+	n.CastlingRights = castlingRightsAfterMove(p.CastlingRights, move, p.IsWhiteTurn, p.castlingFiles())
+	n.EnPassantTile = NoEnPassantTile
+	return n
+}
+
+// settle sets the board and removes rights which are not possible with figures on it
+func (p *Position) settle(board *Board, lastMove *Move) {
+	p.Board = *board
+	p.CastlingRights = castlingRightsOnBoard(p.CastlingRights, board, p.castlingFiles())
+	p.EnPassantTile = NoEnPassantTile
+	if lastMove != nil {
+		p.EnPassantTile = enPassantTile(board, p.IsWhiteTurn, &[]Move{*lastMove})
+	}
+}
+
+// enPassantMove returns the opponents pawn double move after which the en passant capture on the tile is possible
+func (p *Position) enPassantMove() *Move {
+	if p.EnPassantTile == NoEnPassantTile {
+		return nil
+	}
+
+	file, figureRank, destRank := p.EnPassantTile[:1], "7", "5"
+	if !p.IsWhiteTurn {
+		figureRank, destRank = "2", "4"
+	}
+
+	return &Move{Figure: ColoredFigure(Pawn, !p.IsWhiteTurn), FigureFile: file, FigureRank: figureRank,
+		DestinationFile: file, DestinationRank: destRank}
+}
+
+func castlingRightsAfterMove(rights string, move *Move, isWhite bool, files CastlingFiles) string {
+	lost := ""
+	if move.IsKingSideCastling || move.IsQueenSideCastling || IsFigureType(move.Figure, King) {
+		lost = WhiteKingSideCastling + WhiteQueenSideCastling
+		if !isWhite {
+			lost = BlackKingSideCastling + BlackQueenSideCastling
+		}
+	}
+
+	// The castling right is also lost when the rook leaves its starting tile or is captured on it
+	homeTiles := map[string]string{files.KingSideRook + "1": WhiteKingSideCastling,
+		files.QueenSideRook + "1": WhiteQueenSideCastling, files.KingSideRook + "8": BlackKingSideCastling,
+		files.QueenSideRook + "8": BlackQueenSideCastling}
+	lost += homeTiles[move.FigureFile+move.FigureRank] + homeTiles[move.DestinationFile+move.DestinationRank]
+
+	return filterCastlingRights(rights, func(r string) bool { return !strings.Contains(lost, r) })
+}
+
+// The figures must also be on their starting tiles for castling to be possible at all
+func castlingRightsOnBoard(rights string, board *Board, files CastlingFiles) string {
+	kingCol := BoardFileToColumn(files.King)
+	kingSideCol, queenSideCol := BoardFileToColumn(files.KingSideRook), BoardFileToColumn(files.QueenSideRook)
+	figuresInPlace := map[string]bool{
+		WhiteKingSideCastling:  board[7][kingCol] == WhiteFigure(King) && board[7][kingSideCol] == WhiteFigure(Rook),
+		WhiteQueenSideCastling: board[7][kingCol] == WhiteFigure(King) && board[7][queenSideCol] == WhiteFigure(Rook),
+		BlackKingSideCastling:  board[0][kingCol] == BlackFigure(King) && board[0][kingSideCol] == BlackFigure(Rook),
+		BlackQueenSideCastling: board[0][kingCol] == BlackFigure(King) && board[0][queenSideCol] == BlackFigure(Rook),
+	}
+
+	return filterCastlingRights(rights, func(r string) bool { return figuresInPlace[r] })
+}
+
+func playerCastlingRights(rights string, isWhite bool) CastlingRights {
+	kingSide, queenSide := WhiteKingSideCastling, WhiteQueenSideCastling
+	if !isWhite {
+		kingSide, queenSide = BlackKingSideCastling, BlackQueenSideCastling
+	}
+
+	return CastlingRights{KingSide: strings.Contains(rights, kingSide), QueenSide: strings.Contains(rights, queenSide)}
+}
+
+func filterCastlingRights(rights string, keep func(r string) bool) string {
+	result := ""
+	for _, r := range []string{WhiteKingSideCastling, WhiteQueenSideCastling, BlackKingSideCastling,
+		BlackQueenSideCastling} {
+		if strings.Contains(rights, r) && keep(r) {
+			result += r
+		}
+	}
+
+	if result == "" {
+		return NoCastlingRights
+	}
+	return result
+}
+
+// The en passant tile is set only when the capture is actually possible, as required by the repetition rules
+func enPassantTile(board *Board, isWhiteTurn bool, moveHistory *[]Move) string {
+	if len(*moveHistory) == 0 {
+		return NoEnPassantTile
+	}
+
+	lastMove := (*moveHistory)[len(*moveHistory)-1]
+	if !IsFigureType(lastMove.Figure, Pawn) || lastMove.FigureFile != lastMove.DestinationFile ||
+		lastMove.FigureRank == "" {
+		return NoEnPassantTile
+	}
+
+	figureRow, destRow := BoardRankToRow(lastMove.FigureRank), BoardRankToRow(lastMove.DestinationRank)
+	if figureRow-destRow != 2 && destRow-figureRow != 2 {
+		return NoEnPassantTile
+	}
+
+	destCol := BoardFileToColumn(lastMove.DestinationFile)
+	passedRow := (figureRow + destRow) / 2
+	for _, col := range []int{destCol - 1, destCol + 1} {
+		if col < 0 || col > 7 || board[destRow][col] != ColoredFigure(Pawn, isWhiteTurn) {
+			continue
+		}
+		if validateEnPassantMove(board, destRow, col, passedRow, destCol, isWhiteTurn, moveHistory) == nil {
+			return fmt.Sprintf("%s%s", BoardColumnToFile(destCol), BoardRowToRank(passedRow))
+		}
+	}
+
+	return NoEnPassantTile
+}
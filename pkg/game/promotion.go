@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+)
+
+// PromotionFigures are the figures to which the pawn can be promoted, starting with the most valuable one
+var PromotionFigures = []string{Queen, Rook, Bishop, Knight}
+
+// RequiresPromotion godoc
+// Checks whether the move of the player on turn in Standard Algebraic Notation or normalized format is the pawn move
+// to the last rank entered without the figure to promote the pawn to (e.g. e8 or Pe7e8), which is legal once the
+// figure is added to it
+func (g *Game) RequiresPromotion(move string) bool {
+	m, err := parseSANMove(move)
+	if err != nil {
+		m, err = parseMove(move)
+	}
+	if err != nil || m.IsDrop || m.PromotedToFigure != "" || !IsFigureType(m.Figure, Pawn) {
+		return false
+	}
+
+	return g.hasPromotionMove(m)
+}
+
+// WithPromotion godoc
+// Adds the figure to promote the pawn to to the move in Standard Algebraic Notation or normalized format, before the
+// king check or checkmate mark (e.g. e8 -> e8=Q, dxc1+ -> dxc1=N+, Pe7e8 -> Pe7e8Q)
+func WithPromotion(move string, figure string) string {
+	trimmed := strings.TrimRight(move, KingCheckSign+CheckmateSign)
+	suffix := move[len(trimmed):]
+	if _, err := parseSANMove(move); err == nil {
+		return trimmed + SANPromotionSign + strings.ToUpper(figure) + suffix
+	}
+	return trimmed + strings.ToUpper(figure) + suffix
+}
+
+// hasPromotionMove checks whether any legal promotion of the player on turn matches the pawn move without the figure
+func (g *Game) hasPromotionMove(move *Move) bool {
+	for _, m := range g.LegalMoves() {
+		if m.PromotedToFigure != "" && m.DestinationFile == move.DestinationFile &&
+			m.DestinationRank == move.DestinationRank && (move.FigureFile == "" || m.FigureFile == move.FigureFile) &&
+			(move.FigureRank == "" || m.FigureRank == move.FigureRank) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+const promotionFEN = "3r4/4P3/8/8/8/k3P3/8/K7 w - - 0 1"
+
+func TestUnpromotedPawnMove(t *testing.T) {
+	for _, m := range []string{"e8", "Pe7e8", "exd8"} {
+		g, _ := MakeGameFromFEN(promotionFEN, []string{})
+		_, _, err := g.MakeMove(m, true)
+		utils.AssertTestCondition(t, true, err != nil && strings.HasSuffix(err.Error(), ErrPromotionRequired.Error()),
+			"Pawn move to the last rank without promotion should be rejected")
+	}
+
+	g, _ := MakeGameFromFEN(promotionFEN, []string{})
+	move, _, err := g.MakeMove("exd8=N", true)
+	utils.AssertTestCondition(t, nil, err, "Pawn capture with promotion should be valid")
+	utils.AssertTestCondition(t, "Pe7xd8N", move, "Pawn should be promoted to the chosen figure")
+}
+
+func TestPromotionBeforeLastRank(t *testing.T) {
+	g, _ := MakeGameFromFEN(promotionFEN, []string{})
+	_, _, err := g.MakeMove("e4=Q", true)
+	utils.AssertTestCondition(t, true, err != nil, "Pawn move before the last rank should not be a promotion")
+
+	_, err = MakeGameFromFEN(promotionFEN, []string{"Pe7e8"})
+	utils.AssertTestCondition(t, true, err != nil, "Unpromoted pawn move to the last rank should not be replayed")
+
+	_, err = MakeGameFromFEN(promotionFEN, []string{"Pe3e4Q"})
+	utils.AssertTestCondition(t, true, err != nil, "Promotion before the last rank should not be replayed")
+}
+
+func TestUnpromotedVariantPawnMove(t *testing.T) {
+	g, _ := ReplayVariantGameFromFEN(AtomicVariant, promotionFEN, []string{})
+	_, _, err := g.MakeMove("e8", true)
+	utils.AssertTestCondition(t, true, err != nil && strings.HasSuffix(err.Error(), ErrPromotionRequired.Error()),
+		"Variant pawn move to the last rank without promotion should be rejected")
+}
+
+func TestRequiresPromotion(t *testing.T) {
+	g, _ := MakeGameFromFEN(promotionFEN, []string{})
+	utils.AssertTestCondition(t, true, g.RequiresPromotion("e8"), "Pawn move to the last rank requires promotion")
+	utils.AssertTestCondition(t, true, g.RequiresPromotion("exd8+"), "Pawn capture to the last rank requires promotion")
+	utils.AssertTestCondition(t, true, g.RequiresPromotion("Pe7e8"), "Normalized move requires promotion")
+	utils.AssertTestCondition(t, false, g.RequiresPromotion("e8=Q"), "Promotion already has the figure")
+	utils.AssertTestCondition(t, false, g.RequiresPromotion("e4"), "Pawn move before the last rank is not promotion")
+	utils.AssertTestCondition(t, false, g.RequiresPromotion("Kb1"), "Figure move is not promotion")
+}
+
+func TestWithPromotion(t *testing.T) {
+	utils.AssertTestCondition(t, "e8=Q", WithPromotion("e8", Queen), "Promotion should be added to SAN move")
+	utils.AssertTestCondition(t, "dxc1=N+", WithPromotion("dxc1+", "n"), "Promotion should precede the check mark")
+	utils.AssertTestCondition(t, "Pe7e8R", WithPromotion("Pe7e8", Rook), "Promotion should be added to normalized move")
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The columns of the Lichess puzzle database in CSV format, where the columns after themes are not used
+const (
+	lichessPuzzleIdColumn = iota
+	lichessPuzzleFENColumn
+	lichessPuzzleMovesColumn
+	lichessPuzzleRatingColumn
+	lichessPuzzleRatingDeviationColumn
+	lichessPuzzlePopularityColumn
+	lichessPuzzlePlaysColumn
+	lichessPuzzleThemesColumn
+)
+
+// Puzzle godoc
+// The tactics puzzle starting from the position in FEN with the player on turn to solve it. The solution contains the
+// moves in normalized format, where the moves of the player alternate with the replies of the opponent.
+type Puzzle struct {
+	Id       string
+	Fen      string
+	Solution []string
+	Rating   int
+	Themes   []string
+}
+
+// PuzzleProgress godoc
+// The progress of the attempt to solve the puzzle after the moves of the player. The reply of the opponent in
+// normalized format and the position after it are set while the puzzle is neither solved nor failed.
+type PuzzleProgress struct {
+	IsSolved bool
+	IsFailed bool
+	Reply    string
+	Fen      string
+}
+
+// ParseLichessPuzzles godoc
+// Parses the puzzles in the CSV format of the Lichess puzzle database with the columns PuzzleId, FEN, Moves, Rating,
+// RatingDeviation, Popularity, NbPlays, Themes, GameUrl and OpeningTags, where the header line is optional. The FEN is
+// the position before the move of the opponent, which is the first of the moves in UCI format, so the puzzle starts
+// from the position after it.
+func ParseLichessPuzzles(data string) ([]Puzzle, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	puzzles := make([]Puzzle, 0)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && record[lichessPuzzleIdColumn] == "PuzzleId" {
+			continue
+		}
+
+		p, err := parseLichessPuzzle(record)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid puzzle on line %d: %s", line, err.Error()))
+		}
+		puzzles = append(puzzles, *p)
+	}
+
+	return puzzles, nil
+}
+
+func parseLichessPuzzle(record []string) (*Puzzle, error) {
+	if len(record) <= lichessPuzzleRatingColumn {
+		return nil, errors.New(fmt.Sprintf("expected at least %d columns, got %d", lichessPuzzleRatingColumn+1,
+			len(record)))
+	}
+
+	rating, err := strconv.Atoi(record[lichessPuzzleRatingColumn])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid rating: %s", record[lichessPuzzleRatingColumn]))
+	}
+
+	uciMoves := strings.Fields(record[lichessPuzzleMovesColumn])
+	if len(uciMoves) < 2 {
+		return nil, errors.New("puzzle must have the move of the opponent and at least one move of the player")
+	}
+
+	g, err := MakeGameFromFEN(record[lichessPuzzleFENColumn], []string{})
+	if err != nil {
+		return nil, err
+	}
+	if g.Position().Variant != nil {
+		return nil, errors.New("only standard chess puzzles are supported")
+	}
+
+	fen := ""
+	solution := make([]string, 0)
+	for i, uci := range uciMoves {
+		isWhite := g.Position().IsWhiteTurn
+		move, e := g.UCIToMove(uci, isWhite)
+		if e != nil {
+			return nil, e
+		}
+		move, _, e = g.MakeMove(move, isWhite)
+		if e != nil {
+			return nil, e
+		}
+
+		if i == 0 {
+			fen = g.FEN()
+		} else {
+			solution = append(solution, move)
+		}
+	}
+
+	themes := make([]string, 0)
+	if len(record) > lichessPuzzleThemesColumn {
+		themes = strings.Fields(record[lichessPuzzleThemesColumn])
+	}
+
+	return &Puzzle{Id: record[lichessPuzzleIdColumn], Fen: fen, Solution: solution, Rating: rating,
+		Themes: themes}, nil
+}
+
+// CheckPuzzleMoves godoc
+// Replays the moves of the player in Standard Algebraic Notation, UCI or normalized format from the starting position
+// of the puzzle, each followed by the reply of the opponent from the solution. The puzzle is failed by the first move
+// which differs from the solution, except for the move giving the checkmate, which always solves the puzzle. The
+// illegal moves are returned as errors and do not fail the puzzle.
+func CheckPuzzleMoves(fen string, solution []string, moves []string) (*PuzzleProgress, error) {
+	g, err := MakeGameFromFEN(fen, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(moves) == 0 {
+		return &PuzzleProgress{Fen: g.FEN()}, nil
+	}
+	if 2*(len(moves)-1) >= len(solution) {
+		return nil, errors.New(fmt.Sprintf("puzzle is finished after %d moves of the player", (len(solution)+1)/2))
+	}
+
+	for i, m := range moves {
+		isWhite := g.Position().IsWhiteTurn
+		if uciMoveRegex.MatchString(m) {
+			if m, err = g.UCIToMove(m, isWhite); err != nil {
+				return nil, err
+			}
+		}
+
+		move, outcome, e := g.MakeMove(m, isWhite)
+		if e != nil {
+			return nil, e
+		}
+		if outcome == CheckmateOutcome {
+			return &PuzzleProgress{IsSolved: true}, nil
+		}
+		if !isPuzzleMove(move, solution[2*i]) {
+			return &PuzzleProgress{IsFailed: true}, nil
+		}
+		if 2*i+1 == len(solution) {
+			return &PuzzleProgress{IsSolved: true}, nil
+		}
+
+		reply := solution[2*i+1]
+		_, _, e = g.MakeMove(reply, !isWhite)
+		if e != nil {
+			return nil, e
+		}
+		if i == len(moves)-1 {
+			return &PuzzleProgress{Reply: reply, Fen: g.FEN()}, nil
+		}
+	}
+
+	return nil, errors.New("puzzle moves could not be checked")
+}
+
+// The normalized moves are compared without the check and checkmate marks
+func isPuzzleMove(move string, solutionMove string) bool {
+	return strings.TrimRight(move, KingCheckSign+CheckmateSign) ==
+		strings.TrimRight(solutionMove, KingCheckSign+CheckmateSign)
+}
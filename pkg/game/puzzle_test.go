@@ -0,0 +1,58 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+const lichessPuzzles = `PuzzleId,FEN,Moves,Rating,RatingDeviation,Popularity,NbPlays,Themes,GameUrl,OpeningTags
+00008,r6k/pp2r2p/4Rp1Q/3p4/8/1N1P2R1/PqP2bPP/7K b - - 0 24,f2g3 e6e7 b2b1 b3c1 b1c1 h6c1,1913,75,94,6080,crushing hangingPiece long middlegame,https://lichess.org/787zsVup/black#47,
+0000D,5rk1/1p3ppp/pq3b2/8/8/1P1Q1N2/P4PPP/3R2K1 w - - 2 27,d3d6 f8d8 d6d8 f6d8,1480,75,96,23540,advantage endgame short,https://lichess.org/F8M8OS71#53,
+`
+
+func TestParseLichessPuzzles(t *testing.T) {
+	puzzles, err := ParseLichessPuzzles(lichessPuzzles)
+	utils.AssertTestCondition(t, nil, err, "Lichess puzzles should be parsed")
+	utils.AssertTestCondition(t, 2, len(puzzles), "Header line should be skipped")
+
+	p := puzzles[0]
+	utils.AssertTestCondition(t, "00008", p.Id, "Puzzle ID should be parsed")
+	utils.AssertTestCondition(t, 1913, p.Rating, "Puzzle rating should be parsed")
+	utils.AssertTestCondition(t, "r6k/pp2r2p/4Rp1Q/3p4/8/1N1P2b1/PqP3PP/7K w - - 0 25", p.Fen,
+		"Puzzle should start after the move of the opponent")
+	utils.AssertTestCondition(t, "Re6xe7 qb2b1+ Nb3c1 qb1xc1+ Qh6xc1", strings.Join(p.Solution, " "),
+		"Solution should be converted to normalized moves")
+	utils.AssertTestCondition(t, "crushing hangingPiece long middlegame", strings.Join(p.Themes, " "),
+		"Puzzle themes should be parsed")
+}
+
+func TestParseInvalidLichessPuzzle(t *testing.T) {
+	_, err := ParseLichessPuzzles("0000A,8/8/8/8/8/8/8/8 w - - 0 1,e2e4 e7e5,1500")
+	utils.AssertTestCondition(t, true, err != nil, "Puzzle with invalid position should not be parsed")
+
+	_, err = ParseLichessPuzzles("0000B,5rk1/1p3ppp/pq3b2/8/8/1P1Q1N2/P4PPP/3R2K1 w - - 2 27,d3d6 f8d1,1480")
+	utils.AssertTestCondition(t, true, err != nil, "Puzzle with illegal moves should not be parsed")
+}
+
+func TestCheckPuzzleMoves(t *testing.T) {
+	puzzles, _ := ParseLichessPuzzles(lichessPuzzles)
+	p := puzzles[1]
+
+	progress, err := CheckPuzzleMoves(p.Fen, p.Solution, []string{"Rd8"})
+	utils.AssertTestCondition(t, nil, err, "Correct move should be checked")
+	utils.AssertTestCondition(t, false, progress.IsSolved || progress.IsFailed, "Puzzle should not be finished")
+	utils.AssertTestCondition(t, p.Solution[1], progress.Reply, "Opponent should reply with the solution move")
+
+	progress, _ = CheckPuzzleMoves(p.Fen, p.Solution, []string{"f8d8", "f6d8"})
+	utils.AssertTestCondition(t, true, progress.IsSolved, "Puzzle should be solved by the moves in UCI format")
+
+	progress, _ = CheckPuzzleMoves(p.Fen, p.Solution, []string{"g6"})
+	utils.AssertTestCondition(t, true, progress.IsFailed, "Puzzle should be failed by the wrong move")
+
+	_, err = CheckPuzzleMoves(p.Fen, p.Solution, []string{"Rd7"})
+	utils.AssertTestCondition(t, true, err != nil, "Illegal move should return an error")
+
+	_, err = CheckPuzzleMoves(p.Fen, p.Solution, []string{"Rd8", "Bxd8", "h6"})
+	utils.AssertTestCondition(t, true, err != nil, "Moves after the solution should return an error")
+}
@@ -6,9 +6,12 @@ import (
 	"strings"
 )
 
+// ErrPromotionRequired is returned for the pawn move to the last rank without the figure to promote the pawn to
+var ErrPromotionRequired = errors.New("pawn moving to the last rank must be promoted (e.g. e8=Q)")
+
 func ValidateMove(board *Board, move *Move, isWhite bool, moveHistory *[]Move) error {
 	if move.IsKingSideCastling || move.IsQueenSideCastling {
-		return validateCastlingMove(board, move.IsKingSideCastling, isWhite, moveHistory)
+		return validateCastlingMove(board, move, isWhite, moveHistory)
 	}
 
 	destRow, destCol, figureRow, figureCol := destAndFigurePositions(board, move, isWhite)
@@ -22,7 +25,16 @@ func ValidateMove(board *Board, move *Move, isWhite bool, moveHistory *[]Move) e
 		return errors.New("not players figure")
 	}
 
-	err := validateFigureMove(board, move, figureRow, figureCol, destRow, destCol, isWhite)
+	var err error
+	isEnPassant := IsFigureType(move.Figure, Pawn) && destCol != figureCol && board[destRow][destCol] == Empty
+	if isEnPassant {
+		err = validatePromotion(destRow, isWhite, move.PromotedToFigure)
+		if err == nil {
+			err = validateEnPassantMove(board, figureRow, figureCol, destRow, destCol, isWhite, moveHistory)
+		}
+	} else {
+		err = validateFigureMove(board, move, figureRow, figureCol, destRow, destCol, isWhite)
+	}
 
 	if err == nil && board[destRow][destCol] != Empty {
 		if IsPlayersFigure(board[destRow][destCol], isWhite) {
@@ -33,31 +45,30 @@ func ValidateMove(board *Board, move *Move, isWhite bool, moveHistory *[]Move) e
 		}
 	}
 
+	// The moves of the king and the en passant captures check the safety of the king in their own rules
+	if err == nil && !isEnPassant && !IsFigureType(board[figureRow][figureCol], King) &&
+		willKingBeInCheck(board, figureRow, figureCol, destRow, destCol, isWhite) {
+		return errors.New("cannot move figure because king would be under check")
+	}
+
 	return err
 }
 
 func ExecuteMove(board *Board, move *Move, isWhite bool) {
 	if move.IsKingSideCastling || move.IsQueenSideCastling {
-		kingsCol := 4
-		kingsRow := 0
-		if isWhite {
-			kingsRow = 7
+		kingsRow, kingsCol := castlingKingPosition(board, isWhite)
+		rookCol, kingDestCol, rookDestCol := castlingColumns(move)
+		if kingsCol == -1 {
+			return
 		}
 
-		kingFigure := board[kingsRow][kingsCol]
-		if move.IsKingSideCastling {
-			rookCol := 7
-			board[kingsRow][kingsCol+2] = kingFigure
-			board[kingsRow][kingsCol] = Empty
-			board[kingsRow][rookCol-2] = board[kingsRow][rookCol]
-			board[kingsRow][rookCol] = Empty
-		} else {
-			rookCol := 0
-			board[kingsRow][kingsCol-2] = kingFigure
-			board[kingsRow][kingsCol] = Empty
-			board[kingsRow][rookCol+3] = board[kingsRow][rookCol]
-			board[kingsRow][rookCol] = Empty
-		}
+		// Both figures are removed first, since the king can land on the starting tile of the rook in Chess960
+		kingFigure, rookFigure := board[kingsRow][kingsCol], board[kingsRow][rookCol]
+		board[kingsRow][kingsCol] = Empty
+		board[kingsRow][rookCol] = Empty
+		board[kingsRow][kingDestCol] = kingFigure
+		board[kingsRow][rookDestCol] = rookFigure
+		move.Figure = ColoredFigure(King, isWhite)
 
 		return
 	}
@@ -77,6 +88,13 @@ func ExecuteMove(board *Board, move *Move, isWhite bool) {
 
 	move.Figure = ColoredFigure(move.Figure, isWhite)
 
+	// The pawn moving diagonally to an empty tile can only be an en passant capture of the pawn beside it
+	if IsFigureType(move.Figure, Pawn) && destCol != figureCol && board[destRow][destCol] == Empty {
+		board[figureRow][destCol] = Empty
+		move.IsCapture = true
+		move.IsEnPassant = true
+	}
+
 	if move.PromotedToFigure != "" && IsFigureType(move.Figure, Pawn) && (destRow == 0 || destRow == 7) {
 		board[destRow][destCol] = ColoredFigure(move.PromotedToFigure, isWhite)
 	} else {
@@ -88,56 +106,89 @@ func ExecuteMove(board *Board, move *Move, isWhite bool) {
 }
 
 func IsGameWon(board *Board, isWhite bool) bool {
-	if !IsKingCheck(board, !isWhite) {
+	return IsKingCheck(board, !isWhite) && !hasAnyValidMove(board, !isWhite)
+}
+
+func IsStalemate(board *Board, isWhite bool) bool {
+	return !IsKingCheck(board, !isWhite) && !hasAnyValidMove(board, !isWhite)
+}
+
+// IsInsufficientMaterial godoc
+// Checks whether the position is dead because neither player has enough figures to checkmate the opponent by any
+// sequence of legal moves (e.g. king against king, king and bishop or knight against king)
+func IsInsufficientMaterial(board *Board) bool {
+	return !HasMatingMaterial(board, true) && !HasMatingMaterial(board, false)
+}
+
+// HasMatingMaterial godoc
+// Checks whether the player has enough figures to checkmate the opponent by any sequence of legal moves, even with
+// the help of the opponents figures (e.g. king and knight can checkmate the king blocked by its own figures)
+func HasMatingMaterial(board *Board, isWhite bool) bool {
+	own := countFigures(board, isWhite)
+	opponent := countFigures(board, !isWhite)
+
+	if own[Pawn]+own[Rook]+own[Queen] > 0 {
+		return true
+	}
+
+	minorFigures := own[Knight] + own[Bishop]
+	if minorFigures == 0 {
 		return false
 	}
 
-	for i := 0; i < 8; i++ {
-		for j := 0; j < 8; j++ {
-			opponentFigure := board[i][j]
-			if !IsPlayersFigure(opponentFigure, !isWhite) {
-				continue
-			}
+	// Bishops all on the same tile color can never attack the tiles of the other color
+	if own[Knight] == 0 && opponent[Knight]+opponent[Pawn]+opponent[Rook]+opponent[Queen] == 0 &&
+		areBishopsOnSameTileColor(board) {
+		return false
+	}
 
-			for k := 0; k < 8; k++ {
-				for m := 0; m < 8; m++ {
-					if IsPlayersFigure(board[k][m], !isWhite) {
-						continue
-					}
-
-					move := Move{Figure: opponentFigure, PromotedToFigure: Queen}
-					err := validateFigureMove(board, &move, i, j, k, m, !isWhite)
-					if err == nil && !willKingBeInCheck(board, i, j, k, m, !isWhite) {
-						return false
-					}
-				}
-			}
-		}
+	if minorFigures == 1 && opponent[Pawn]+opponent[Knight]+opponent[Bishop]+opponent[Rook]+opponent[Queen] == 0 {
+		return false
 	}
 
 	return true
 }
 
 func IsKingCheck(board *Board, isWhite bool) bool {
-	kingRow, kingCol := findFigureRowAndColumn(board, King, "", "", isWhite)
-	if kingRow == -1 || kingCol == -1 {
-		return false
-	}
+	b := makeBitboards(board)
+	return b.isKingAttacked(sideOf(isWhite))
+}
+
+// The castling and en passant capture are not known from the board alone, which is fine for the mate detection since
+// castling is never possible while in check. The game uses the moves of its full position instead.
+func hasAnyValidMove(board *Board, isWhite bool) bool {
+	position := Position{Board: *board, IsWhiteTurn: isWhite, CastlingRights: NoCastlingRights,
+		EnPassantTile: NoEnPassantTile}
+	return len(LegalMoves(&position)) > 0
+}
 
+func countFigures(board *Board, isWhite bool) map[string]int {
+	count := make(map[string]int)
 	for i := 0; i < 8; i++ {
 		for j := 0; j < 8; j++ {
-			figure := board[i][j]
-			if IsPlayersFigure(figure, !isWhite) {
-				move := Move{Figure: figure, PromotedToFigure: Queen}
-				err := validateFigureMove(board, &move, i, j, kingRow, kingCol, !isWhite)
-				if err == nil {
-					return true
-				}
+			if IsPlayersFigure(board[i][j], isWhite) {
+				count[strings.ToUpper(board[i][j])]++
 			}
 		}
 	}
+	return count
+}
 
-	return false
+func areBishopsOnSameTileColor(board *Board) bool {
+	tileColor := -1
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if !IsFigureType(board[i][j], Bishop) {
+				continue
+			}
+			if tileColor == -1 {
+				tileColor = (i + j) % 2
+			} else if tileColor != (i+j)%2 {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func validateFigureMove(board *Board, move *Move, figureRow int, figureCol int, destRow int, destCol int, isWhite bool) error {
@@ -164,16 +215,16 @@ func validateFigureMove(board *Board, move *Move, figureRow int, figureCol int,
 }
 
 func validatePawnsMove(board *Board, figureRow int, figureCol int, destRow int, destCol int, isWhite bool, promoteToFigure string) error {
-	if isWhite {
-		if destRow == 0 && promoteToFigure == "" {
-			return errors.New("move to this position must be a promotion")
-		}
+	if err := validatePromotion(destRow, isWhite, promoteToFigure); err != nil {
+		return err
+	}
 
+	if isWhite {
 		if destRow-figureRow > 0 {
 			return errors.New("pawn can only move forward")
 		}
 
-		if (figureRow == 6 && destRow-figureRow < -2) || (figureRow < 6 && destRow-figureRow > -1) {
+		if (figureRow == 6 && destRow-figureRow < -2) || (figureRow < 6 && destRow-figureRow < -1) {
 			return errors.New("pawn can only move 1 tile forward (or 2 for its first move)")
 		}
 
@@ -191,10 +242,6 @@ func validatePawnsMove(board *Board, figureRow int, figureCol int, destRow int,
 			}
 		}
 	} else {
-		if destRow == 7 && promoteToFigure == "" {
-			return errors.New("move to this position must be a promotion")
-		}
-
 		if destRow-figureRow < 0 {
 			return errors.New("pawn can only move forward")
 		}
@@ -221,6 +268,39 @@ func validatePawnsMove(board *Board, figureRow int, figureCol int, destRow int,
 	return nil
 }
 
+func validateEnPassantMove(board *Board, figureRow int, figureCol int, destRow int, destCol int, isWhite bool,
+	moveHistory *[]Move) error {
+	direction, captureRow := 1, 4
+	if isWhite {
+		direction, captureRow = -1, 3
+	}
+
+	if math.Abs(float64(destCol-figureCol)) != 1 || destRow-figureRow != direction {
+		return errors.New("pawn can capture only diagonally adjacent tiles")
+	}
+
+	if figureRow != captureRow || board[figureRow][destCol] != ColoredFigure(Pawn, !isWhite) {
+		return errors.New("pawn can only move diagonally to capture other players figure")
+	}
+
+	if moveHistory == nil || len(*moveHistory) == 0 {
+		return errors.New("en passant capture is allowed only right after opponents pawn double move")
+	}
+
+	lastMove := (*moveHistory)[len(*moveHistory)-1]
+	if !IsFigureType(lastMove.Figure, Pawn) || lastMove.FigureFile != BoardColumnToFile(destCol) ||
+		lastMove.DestinationFile != BoardColumnToFile(destCol) || lastMove.DestinationRank != BoardRowToRank(figureRow) ||
+		lastMove.FigureRank != BoardRowToRank(figureRow+2*direction) {
+		return errors.New("en passant capture is allowed only right after opponents pawn double move")
+	}
+
+	if willKingBeInCheckAfterEnPassant(board, figureRow, figureCol, destRow, destCol, isWhite) {
+		return errors.New("cannot capture en passant because king would be under check")
+	}
+
+	return nil
+}
+
 func validateKnightsMove(board *Board, figureRow int, figureCol int, destRow int, destCol int) error {
 	rowDiff, colDiff := rowAndColDiffs(figureRow, figureCol, destRow, destCol)
 
@@ -306,63 +386,120 @@ func validateQueensMove(board *Board, figureRow int, figureCol int, destRow int,
 	return nil
 }
 
-func validateCastlingMove(board *Board, isKingSide bool, isWhite bool, moveHistory *[]Move) error {
-	for _, m := range *moveHistory {
-		if m.IsKingSideCastling || m.IsQueenSideCastling {
-			return errors.New("castling has already been done")
-		}
-		if IsFigureType(m.Figure, King) {
-			return errors.New("cannot castle anymore because king has been moved")
-		}
-		if IsFigureType(m.Figure, Rook) && ((isKingSide && m.FigureFile == "h") || (!isKingSide && m.FigureFile == "a")) {
-			return errors.New("cannot castle anymore because rook has been moved")
-		}
+func validateCastlingMove(board *Board, move *Move, isWhite bool, moveHistory *[]Move) error {
+	kingsRow, kingsCol := castlingKingPosition(board, isWhite)
+	rookCol, kingDestCol, rookDestCol := castlingColumns(move)
+
+	if kingsCol == -1 || (move.CastlingRookFile == "" && kingsCol != 4) ||
+		board[kingsRow][rookCol] != ColoredFigure(Rook, isWhite) ||
+		(move.IsKingSideCastling && rookCol < kingsCol) || (move.IsQueenSideCastling && rookCol > kingsCol) {
+		return errors.New("cannot castle without king and rook on their starting tiles")
 	}
 
-	kingsCol := 4
-	kingsRow := 0
-	if isWhite {
-		kingsRow = 7
+	files := StandardCastlingFiles
+	files.King = BoardColumnToFile(kingsCol)
+	if move.IsKingSideCastling {
+		files.KingSideRook = BoardColumnToFile(rookCol)
+	} else {
+		files.QueenSideRook = BoardColumnToFile(rookCol)
 	}
 
-	colsToCheck := []int{5, 6}
-	if !isKingSide {
-		colsToCheck = []int{1, 2, 3}
+	rights := castlingRightsFromHistory(moveHistory, isWhite, files)
+	if (move.IsKingSideCastling && !rights.KingSide) || (move.IsQueenSideCastling && !rights.QueenSide) {
+		return errors.New("cannot castle anymore because king or rook has been moved or rook has been captured")
 	}
 
-	willBeCheck := false
-	for i := 0; i < len(colsToCheck); i++ {
-		if board[kingsRow][colsToCheck[i]] != Empty {
+	// All tiles which the king and the rook pass over or land on must be empty, except the ones they start from
+	for col := min(kingsCol, kingDestCol, rookCol, rookDestCol); col <= max(kingsCol, kingDestCol, rookCol,
+		rookDestCol); col++ {
+		if col != kingsCol && col != rookCol && board[kingsRow][col] != Empty {
 			return errors.New("cannot castle while there are figures between the king and the rook")
 		}
-		if !willBeCheck && willKingBeInCheck(board, kingsRow, kingsCol, kingsRow, colsToCheck[i], isWhite) {
-			willBeCheck = true
+	}
+
+	if IsKingCheck(board, isWhite) {
+		return errors.New("cannot castle while king is in check")
+	}
+
+	step := 1
+	if kingDestCol < kingsCol {
+		step = -1
+	}
+	for col := kingsCol; col != kingDestCol; {
+		col += step
+		if willKingBeInCheck(board, kingsRow, kingsCol, kingsRow, col, isWhite) {
+			return errors.New("cannot castle because at least one tile between king and rook are in check")
 		}
 	}
 
-	if willBeCheck {
+	// The rook leaving its tile in Chess960 can open the line of attack to the destination of the king
+	tempBoard, tempMove := *board, *move
+	ExecuteMove(&tempBoard, &tempMove, isWhite)
+	if IsKingCheck(&tempBoard, isWhite) {
 		return errors.New("cannot castle because at least one tile between king and rook are in check")
 	}
 
-	if IsKingCheck(board, isWhite) {
-		return errors.New("cannot castle while king is in check")
+	return nil
+}
+
+// The castling rights of the player are lost by the own king or rook moves and by the capture of the own rook on its
+// home tile, so the moves of both players are walked by the color of their figure
+func castlingRightsFromHistory(moveHistory *[]Move, isWhite bool, files CastlingFiles) CastlingRights {
+	rights := AllCastlingRights
+	for _, m := range *moveHistory {
+		rights = castlingRightsAfterMove(rights, &m, IsPlayersFigure(m.Figure, true), files)
 	}
+	return playerCastlingRights(rights, isWhite)
+}
 
-	return nil
+func castlingKingPosition(board *Board, isWhite bool) (int, int) {
+	kingsRow, rank := 0, "8"
+	if isWhite {
+		kingsRow, rank = 7, "1"
+	}
+	_, kingsCol := findFigureRowAndColumn(board, King, "", rank, isWhite)
+	return kingsRow, kingsCol
 }
 
-func willKingBeInCheck(board *Board, figureRow int, figureCol int, destRow int, destCol int, isWhite bool) bool {
-	tempBoard := Board{}
-	for i := 0; i < 8; i++ {
-		for j := 0; j < 8; j++ {
-			tempBoard[i][j] = board[i][j]
+// The king and the rook always end on the same tiles as in standard chess, while in Chess960 the rook starts from
+// the file given by the move
+func castlingColumns(move *Move) (int, int, int) {
+	if move.IsKingSideCastling {
+		rookCol := 7
+		if move.CastlingRookFile != "" {
+			rookCol = BoardFileToColumn(move.CastlingRookFile)
 		}
+		return rookCol, 6, 5
 	}
 
-	tempBoard[destRow][destCol] = tempBoard[figureRow][figureCol]
-	tempBoard[figureRow][figureCol] = Empty
+	rookCol := 0
+	if move.CastlingRookFile != "" {
+		rookCol = BoardFileToColumn(move.CastlingRookFile)
+	}
+	return rookCol, 2, 3
+}
 
-	return IsKingCheck(&tempBoard, isWhite)
+func willKingBeInCheck(board *Board, figureRow int, figureCol int, destRow int, destCol int, isWhite bool) bool {
+	b := makeBitboards(board)
+	from, to := figureRow*8+figureCol, destRow*8+destCol
+
+	if side, figureType := figureSideAndType(board[destRow][destCol]); figureType != noFigureType {
+		b.clear(side, figureType, to)
+	}
+	if side, figureType := figureSideAndType(board[figureRow][figureCol]); figureType != noFigureType {
+		b.clear(side, figureType, from)
+		b.set(side, figureType, to)
+	}
+
+	return b.isKingAttacked(sideOf(isWhite))
+}
+
+func willKingBeInCheckAfterEnPassant(board *Board, figureRow int, figureCol int, destRow int, destCol int,
+	isWhite bool) bool {
+	tempBoard := *board
+	tempBoard[figureRow][destCol] = Empty
+
+	return willKingBeInCheck(&tempBoard, figureRow, figureCol, destRow, destCol, isWhite)
 }
 
 func destAndFigurePositions(board *Board, move *Move, isWhite bool) (int, int, int, int) {
@@ -447,3 +584,16 @@ func findFigureRowAndColumn(board *Board, figure string, file string, rank strin
 
 	return figureRow, figureCol
 }
+
+// validatePromotion checks that the pawn moving to the last rank is promoted, and that the pawn moving to any other
+// rank is not
+func validatePromotion(destRow int, isWhite bool, promoteToFigure string) error {
+	isLastRank := (isWhite && destRow == 0) || (!isWhite && destRow == 7)
+	if isLastRank && promoteToFigure == "" {
+		return ErrPromotionRequired
+	}
+	if !isLastRank && promoteToFigure != "" {
+		return errors.New("only the pawn moving to the last rank can be promoted")
+	}
+	return nil
+}
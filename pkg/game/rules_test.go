@@ -244,7 +244,7 @@ func TestWhiteKingSideCastlingMove(t *testing.T) {
 	addFigureToBoard(board, WhiteFigure(King), "e", "1")
 	addFigureToBoard(board, WhiteFigure(Rook), "h", "1")
 
-	err := validateCastlingMove(board, true, true, &[]Move{})
+	err := validateCastlingMove(board, &Move{IsKingSideCastling: true}, true, &[]Move{})
 	utils.AssertTestCondition(t, nil, err, "King side castling move should be valid")
 }
 
@@ -253,7 +253,7 @@ func TestWhiteQueenSideCastlingMove(t *testing.T) {
 	addFigureToBoard(board, WhiteFigure(King), "e", "1")
 	addFigureToBoard(board, WhiteFigure(Rook), "a", "1")
 
-	err := validateCastlingMove(board, false, true, &[]Move{})
+	err := validateCastlingMove(board, &Move{IsQueenSideCastling: true}, true, &[]Move{})
 	utils.AssertTestCondition(t, nil, err, "Queen side castling move should be valid")
 }
 
@@ -262,7 +262,7 @@ func TestBlackKingSideCastlingMove(t *testing.T) {
 	addFigureToBoard(board, BlackFigure(King), "e", "8")
 	addFigureToBoard(board, BlackFigure(Rook), "h", "8")
 
-	err := validateCastlingMove(board, true, false, &[]Move{})
+	err := validateCastlingMove(board, &Move{IsKingSideCastling: true}, false, &[]Move{})
 	utils.AssertTestCondition(t, nil, err, "King side castling move should be valid")
 }
 
@@ -271,10 +271,102 @@ func TestBlackQueenSideCastlingMove(t *testing.T) {
 	addFigureToBoard(board, BlackFigure(King), "e", "8")
 	addFigureToBoard(board, BlackFigure(Rook), "a", "8")
 
-	err := validateCastlingMove(board, false, false, &[]Move{})
+	err := validateCastlingMove(board, &Move{IsQueenSideCastling: true}, false, &[]Move{})
 	utils.AssertTestCondition(t, nil, err, "Queen side castling move should be valid")
 }
 
+func TestCastlingAfterOpponentCastled(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, []string{"e4", "e5", "Nf3", "Nf6", "Bc4", "Bc5", "O-O", "O-O"})
+	utils.AssertTestCondition(t, CastlingRights{}, g.CastlingRights(false), "Black should lose castling rights")
+}
+
+func TestCastlingAfterOpponentKingMove(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, []string{"e4", "e5", "Nf3", "Ke7", "Bc4", "d6", "O-O"})
+	utils.AssertTestCondition(t, CastlingRights{}, g.CastlingRights(true), "White should lose castling rights")
+}
+
+func TestCastlingRightsAfterRookCapture(t *testing.T) {
+	history := []Move{{Figure: BlackFigure(Bishop), FigureFile: "b", FigureRank: "7", DestinationFile: "h",
+		DestinationRank: "1", IsCapture: true}}
+	utils.AssertTestCondition(t, CastlingRights{QueenSide: true},
+		castlingRightsFromHistory(&history, true, StandardCastlingFiles),
+		"White should lose king side castling right after rook capture")
+	utils.AssertTestCondition(t, CastlingRights{KingSide: true, QueenSide: true},
+		castlingRightsFromHistory(&history, false, StandardCastlingFiles),
+		"Black castling rights should not be affected")
+}
+
+func TestCastlingRightsAfterRookMove(t *testing.T) {
+	history := []Move{{Figure: WhiteFigure(Rook), FigureFile: "h", FigureRank: "4", DestinationFile: "h",
+		DestinationRank: "5"}, {Figure: BlackFigure(Rook), FigureFile: "h", FigureRank: "8", DestinationFile: "h",
+		DestinationRank: "6"}}
+	utils.AssertTestCondition(t, CastlingRights{KingSide: true, QueenSide: true},
+		castlingRightsFromHistory(&history, true, StandardCastlingFiles),
+		"Rook move outside of home tile should not lose castling right")
+	utils.AssertTestCondition(t, CastlingRights{QueenSide: true},
+		castlingRightsFromHistory(&history, false, StandardCastlingFiles),
+		"Black should lose king side castling right after rook move")
+}
+
+func TestCastlingRightsFromStoredMoves(t *testing.T) {
+	moves := []string{"Pe2e4", "pe7e5", "Ng1f3", "ng8f6", "Bf1c4", "bf8c5", "0-0", "pd7d6", "Rf1e1", "0-0"}
+	replayed, _ := ReplayGame(MakeStartingBoard(), moves)
+	g, err := MakeGame(replayed.GetTiles(), moves)
+	utils.AssertTestCondition(t, nil, err, "Game should be made without error")
+	utils.AssertTestCondition(t, CastlingRights{}, g.CastlingRights(true), "White should lose castling rights")
+	utils.AssertTestCondition(t, CastlingRights{}, g.CastlingRights(false), "Black should lose castling rights")
+
+	g, _ = MakeGame(MakeStartingBoard(), []string{})
+	utils.AssertTestCondition(t, CastlingRights{KingSide: true, QueenSide: true}, g.CastlingRights(false),
+		"Black should have all castling rights")
+}
+
+func TestWhitePawnEnPassantMove(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(Pawn), "e", "5")
+	addFigureToBoard(board, BlackFigure(Pawn), "d", "5")
+
+	history := []Move{{Figure: BlackFigure(Pawn), FigureFile: "d", FigureRank: "7", DestinationFile: "d",
+		DestinationRank: "5"}}
+	move := Move{Figure: Pawn, FigureFile: "e", DestinationFile: "d", DestinationRank: "6"}
+	err := ValidateMove(board, &move, true, &history)
+	utils.AssertTestCondition(t, nil, err, "Pawn en passant move should be valid")
+
+	ExecuteMove(board, &move, true)
+	utils.AssertTestCondition(t, Empty, board[BoardRankToRow("5")][BoardFileToColumn("d")],
+		"Pawn captured en passant should be removed")
+	utils.AssertTestCondition(t, true, move.IsEnPassant, "Move should be marked as en passant")
+}
+
+func TestBlackPawnEnPassantMove(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, BlackFigure(Pawn), "c", "4")
+	addFigureToBoard(board, WhiteFigure(Pawn), "b", "4")
+
+	history := []Move{{Figure: WhiteFigure(Pawn), FigureFile: "b", FigureRank: "2", DestinationFile: "b",
+		DestinationRank: "4"}}
+	move := Move{Figure: Pawn, FigureFile: "c", DestinationFile: "b", DestinationRank: "3"}
+	err := ValidateMove(board, &move, false, &history)
+	utils.AssertTestCondition(t, nil, err, "Pawn en passant move should be valid")
+}
+
+func TestPawnEnPassantMoveNotAfterDoubleMove(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(Pawn), "e", "5")
+	addFigureToBoard(board, BlackFigure(Pawn), "d", "5")
+
+	history := []Move{
+		{Figure: BlackFigure(Pawn), FigureFile: "d", FigureRank: "7", DestinationFile: "d", DestinationRank: "5"},
+		{Figure: WhiteFigure(King), FigureFile: "e", FigureRank: "1", DestinationFile: "e", DestinationRank: "2"},
+		{Figure: BlackFigure(King), FigureFile: "e", FigureRank: "8", DestinationFile: "e", DestinationRank: "7"},
+	}
+	move := Move{Figure: Pawn, FigureFile: "e", DestinationFile: "d", DestinationRank: "6"}
+	err := ValidateMove(board, &move, true, &history)
+	utils.AssertTestCondition(t, true, err != nil, "Pawn en passant move should be valid only right after double move")
+}
+
 func TestWhiteKingCheck(t *testing.T) {
 	board := makeEmptyBoard()
 	addFigureToBoard(board, WhiteFigure(King), "e", "1")
@@ -306,6 +398,209 @@ func TestGameWon(t *testing.T) {
 	utils.AssertTestCondition(t, true, win, "The white player should have won the game")
 }
 
+func TestGameWonIsNotStalemate(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, BlackFigure(King), "h", "8")
+	addFigureToBoard(board, WhiteFigure(Queen), "g", "7")
+	addFigureToBoard(board, WhiteFigure(King), "g", "6")
+
+	stalemate := IsStalemate(board, true)
+	utils.AssertTestCondition(t, false, stalemate, "The checkmate should not be a stalemate")
+}
+
+func TestStalemate(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, BlackFigure(King), "a", "8")
+	addFigureToBoard(board, WhiteFigure(Queen), "b", "6")
+	addFigureToBoard(board, WhiteFigure(King), "g", "1")
+
+	stalemate := IsStalemate(board, true)
+	utils.AssertTestCondition(t, true, stalemate, "The black player should be in stalemate")
+	utils.AssertTestCondition(t, false, IsGameWon(board, true), "The stalemate should not be a win")
+}
+
+func TestNotStalemateWithOtherFigureMoves(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, BlackFigure(King), "a", "8")
+	addFigureToBoard(board, BlackFigure(Pawn), "h", "7")
+	addFigureToBoard(board, WhiteFigure(Queen), "b", "6")
+	addFigureToBoard(board, WhiteFigure(King), "g", "1")
+
+	stalemate := IsStalemate(board, true)
+	utils.AssertTestCondition(t, false, stalemate, "The black player can still move the pawn")
+}
+
+// The moves of the game Carlsen vs Nakamura, Magnus Carlsen Invitational 2021, drawn by threefold repetition after
+// both players played the "double bongcloud", where the kings return to the same tiles without the castling rights
+var bongcloudRepetitionGame = []string{"Pe2e4", "pe7e5", "Ke1e2", "ke8e7", "Ke2e1", "ke7e8", "Ke1e2", "ke8e7", "Ke2e1",
+	"ke7e8", "Ke1e2", "ke8e7"}
+
+func TestThreefoldRepetitionDrawClaim(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, bongcloudRepetitionGame[:len(bongcloudRepetitionGame)-1])
+	utils.AssertTestCondition(t, NoOutcome, g.ClaimableDraw(), "Draw should not be claimable before third repetition")
+
+	playMoves(t, g, bongcloudRepetitionGame[len(bongcloudRepetitionGame)-1:])
+	utils.AssertTestCondition(t, ThreefoldRepetitionOutcome, g.ClaimableDraw(),
+		"Draw should be claimable after third repetition of the kings position")
+
+	move, outcome, err := g.MakeMove(DrawClaimMove, true)
+	utils.AssertTestCondition(t, nil, err, "Draw claim should be accepted")
+	utils.AssertTestCondition(t, DrawClaimMove, move, "Draw claim move should be returned")
+	utils.AssertTestCondition(t, ThreefoldRepetitionOutcome, outcome, "Game should end with threefold repetition")
+}
+
+func TestRepetitionWithLostCastlingRights(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, bongcloudRepetitionGame[:10])
+	utils.AssertTestCondition(t, 2, g.RepetitionCount(),
+		"Position with lost castling rights should not repeat the position where castling was possible")
+
+	playMoves(t, g, bongcloudRepetitionGame[10:])
+	utils.AssertTestCondition(t, 3, g.RepetitionCount(), "Kings position should be repeated three times")
+}
+
+func TestRepetitionWithLostEnPassantRights(t *testing.T) {
+	// The double pawn move 2...d5 allows the en passant capture exd6, which is no longer possible when the queens
+	// return to their tiles, while the castling rights are kept
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, []string{"Pe2e4", "Pe7e6", "Pe4e5", "Pd7d5", "Qd1e2", "Qd8d7", "Qe2d1", "Qd7d8", "Qd1e2", "Qd8d7",
+		"Qe2d1", "Qd7d8"})
+	utils.AssertTestCondition(t, 2, g.RepetitionCount(),
+		"Position without en passant capture should not repeat the position where it was possible")
+
+	playMoves(t, g, []string{"Qd1e2", "Qd8d7", "Qe2d1", "Qd7d8"})
+	utils.AssertTestCondition(t, ThreefoldRepetitionOutcome, g.ClaimableDraw(),
+		"Draw should be claimable after third repetition without en passant capture")
+}
+
+func TestFivefoldRepetitionAutomaticDraw(t *testing.T) {
+	// The double bongcloud game continued until the kings position is repeated for the fifth time
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, bongcloudRepetitionGame)
+	playMoves(t, g, []string{"Ke2e1", "Ke7e8", "Ke1e2", "Ke8e7", "Ke2e1", "Ke7e8", "Ke1e2"})
+
+	_, outcome, err := g.MakeMove("Ke8e7", false)
+	utils.AssertTestCondition(t, nil, err, "Move should be played without error")
+	utils.AssertTestCondition(t, FivefoldRepetitionOutcome, outcome, "Game should end with fivefold repetition")
+}
+
+func TestReplayedGameRepetition(t *testing.T) {
+	moves := append([]string{}, bongcloudRepetitionGame[:5]...)
+	moves = append(moves, DrawOfferMove, DrawOfferRejectMove)
+	moves = append(moves, bongcloudRepetitionGame[5:]...)
+	g, err := ReplayGame(MakeStartingBoard(), moves)
+	utils.AssertTestCondition(t, nil, err, "Game should be replayed without error")
+	utils.AssertTestCondition(t, 3, g.RepetitionCount(), "Kings position should be repeated three times")
+}
+
+func TestDrawClaimWithoutRepetition(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	playMoves(t, g, []string{"Ng1f3", "Ng8f6"})
+
+	_, _, err := g.MakeMove(DrawClaimMove, true)
+	utils.AssertTestCondition(t, true, err != nil, "Draw claim should be rejected without repetition")
+}
+
+func TestFiftyMoveRuleDrawClaim(t *testing.T) {
+	g, _ := MakeGame("k000000000000000000000000000000000000000000000000000000R0000000K", quietMoves(99))
+	utils.AssertTestCondition(t, NoOutcome, g.ClaimableDraw(), "Draw should not be claimable before fifty moves")
+
+	g, _ = MakeGame("k000000000000000000000000000000000000000000000000000000R0000000K", quietMoves(100))
+	utils.AssertTestCondition(t, FiftyMoveRuleOutcome, g.ClaimableDraw(), "Draw should be claimable after fifty moves")
+}
+
+func TestSeventyFiveMoveRuleAutomaticDraw(t *testing.T) {
+	g, _ := MakeGame("k000000000000000000000000000000000000000000000000000000R0000000K", quietMoves(149))
+
+	_, outcome, err := g.MakeMove("Ka8b8", false)
+	utils.AssertTestCondition(t, nil, err, "Move should be played without error")
+	utils.AssertTestCondition(t, SeventyFiveMoveRuleOutcome, outcome, "Game should end with seventy-five-move rule")
+}
+
+func TestFiftyMoveRuleResetByPawnMove(t *testing.T) {
+	moves := append(quietMoves(99), "Pa2a3")
+	g, _ := MakeGame("k000000000000000000000000000000000000000000000000000000R0000000K", moves)
+	utils.AssertTestCondition(t, 0, g.Position().HalfMoveClock, "Pawn move should reset the half move clock")
+}
+
+func TestInsufficientMaterialKingsOnly(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(King), "e", "1")
+	addFigureToBoard(board, BlackFigure(King), "e", "8")
+
+	utils.AssertTestCondition(t, true, IsInsufficientMaterial(board), "Kings only should be insufficient material")
+}
+
+func TestInsufficientMaterialKingAndMinorFigure(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(King), "e", "1")
+	addFigureToBoard(board, WhiteFigure(Knight), "b", "1")
+	addFigureToBoard(board, BlackFigure(King), "e", "8")
+
+	utils.AssertTestCondition(t, true, IsInsufficientMaterial(board), "King and knight should be insufficient material")
+
+	board[BoardRankToRow("1")][BoardFileToColumn("b")] = WhiteFigure(Bishop)
+	utils.AssertTestCondition(t, true, IsInsufficientMaterial(board), "King and bishop should be insufficient material")
+}
+
+func TestInsufficientMaterialSameColorBishops(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(King), "e", "1")
+	addFigureToBoard(board, WhiteFigure(Bishop), "c", "1")
+	addFigureToBoard(board, BlackFigure(King), "e", "8")
+	addFigureToBoard(board, BlackFigure(Bishop), "f", "8")
+
+	utils.AssertTestCondition(t, true, IsInsufficientMaterial(board),
+		"Bishops on the same tile color should be insufficient material")
+
+	board[BoardRankToRow("8")][BoardFileToColumn("f")] = Empty
+	addFigureToBoard(board, BlackFigure(Bishop), "c", "8")
+	utils.AssertTestCondition(t, false, IsInsufficientMaterial(board),
+		"Bishops on different tile colors should be sufficient material")
+}
+
+func TestSufficientMaterial(t *testing.T) {
+	board := makeEmptyBoard()
+	addFigureToBoard(board, WhiteFigure(King), "e", "1")
+	addFigureToBoard(board, WhiteFigure(Pawn), "a", "2")
+	addFigureToBoard(board, BlackFigure(King), "e", "8")
+
+	utils.AssertTestCondition(t, false, IsInsufficientMaterial(board), "King and pawn should be sufficient material")
+	utils.AssertTestCondition(t, true, HasMatingMaterial(board, true), "White should be able to checkmate")
+	utils.AssertTestCondition(t, false, HasMatingMaterial(board, false), "Black should not be able to checkmate")
+}
+
+func TestInsufficientMaterialGameOutcome(t *testing.T) {
+	g, _ := MakeGame("0000k00000000000000000000000000000000000000000000000r00000000K00", []string{})
+
+	move, outcome, err := g.MakeMove("Kfxe2", true)
+	utils.AssertTestCondition(t, nil, err, "Capture move should be played without error")
+	utils.AssertTestCondition(t, "Kf1xe2", move, "Capture move should be normalized")
+	utils.AssertTestCondition(t, InsufficientMaterialOutcome, outcome, "Game should end with insufficient material")
+}
+
+func playMoves(t *testing.T, g *Game, moves []string) {
+	for _, move := range moves {
+		_, _, err := g.MakeMove(move, len(g.Moves)%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "Move should be played without error")
+	}
+}
+
+// quietMoves returns the legal moves of the white rook between h2 and g2 and the black king between a8 and b8
+func quietMoves(count int) []string {
+	whiteMoves, blackMoves := []string{"Rh2g2", "Rg2h2"}, []string{"ka8b8", "kb8a8"}
+	moves := make([]string, 0)
+	for i := 0; i < count; i++ {
+		if i%2 == 0 {
+			moves = append(moves, whiteMoves[i/2%2])
+		} else {
+			moves = append(moves, blackMoves[i/2%2])
+		}
+	}
+	return moves
+}
+
 func addFigureToBoard(board *Board, figure string, file string, rank string) {
 	col := BoardFileToColumn(file)
 	row := BoardRankToRow(rank)
@@ -0,0 +1,213 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	SANKingSideCastlingMove  = "O-O"
+	SANQueenSideCastlingMove = "O-O-O"
+	SANPromotionSign         = "="
+)
+
+// MakeSANMoves godoc
+// Converts the moves played from the position in Forsyth-Edwards Notation to Standard Algebraic Notation used by
+// the most of chess software (e.g. Pe2e4 -> e4, Ng1f3 -> Nf3, Pe7xd8Q+ -> exd8=Q+). The moves must be in normalized
+// format, while draw offers and claims are skipped since they are not part of the movetext.
+func MakeSANMoves(fen string, moves []string) ([]string, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.SANMoves(moves)
+}
+
+// SANMoves converts the moves in normalized format played from the position to Standard Algebraic Notation, with the
+// rules of the variant played in the position
+func (p *Position) SANMoves(moves []string) ([]string, error) {
+	g := &Game{Board: p.Board, Moves: make([]Move, 0), Positions: []Position{*p}}
+
+	sanMoves := make([]string, 0)
+	isWhite := p.IsWhiteTurn
+	for _, m := range moves {
+		if IsDrawMove(m) {
+			continue
+		}
+		move, e := g.parseReplayMove(m, isWhite)
+		if e != nil {
+			return nil, e
+		}
+		g.executeMove(move, isWhite)
+		sanMoves = append(sanMoves, g.LastMoveSAN())
+		isWhite = !isWhite
+	}
+
+	return sanMoves, nil
+}
+
+// MoveToSAN converts the legal move of the player on turn to Standard Algebraic Notation with the check or checkmate
+// sign, without playing it
+func (p *Position) MoveToSAN(move *Move) string {
+	san := moveToSAN(&p.Board, move, p.IsWhiteTurn)
+	if !move.IsKingCheck {
+		return san
+	}
+
+	if next := p.Play(move); next.IsCheckmate() {
+		return san + CheckmateSign
+	}
+	return san + KingCheckSign
+}
+
+// The move must contain the position of the figure and the board must be in the state before the move is executed
+func moveToSAN(board *Board, move *Move, isWhite bool) string {
+	if move.IsKingSideCastling {
+		return SANKingSideCastlingMove
+	}
+	if move.IsQueenSideCastling {
+		return SANQueenSideCastlingMove
+	}
+	if move.IsDrop {
+		return fmt.Sprintf("%s%s%s%s", strings.ToUpper(move.Figure), DropSign, move.DestinationFile,
+			move.DestinationRank)
+	}
+
+	figureRow, figureCol := BoardRankToRow(move.FigureRank), BoardFileToColumn(move.FigureFile)
+	destRow, destCol := BoardRankToRow(move.DestinationRank), BoardFileToColumn(move.DestinationFile)
+	isCapture := board[destRow][destCol] != Empty || (IsFigureType(move.Figure, Pawn) && figureCol != destCol)
+
+	capture := ""
+	if isCapture {
+		capture = CaptureSign
+	}
+
+	destination := fmt.Sprintf("%s%s", move.DestinationFile, move.DestinationRank)
+
+	if IsFigureType(move.Figure, Pawn) {
+		origin := ""
+		if isCapture {
+			origin = move.FigureFile
+		}
+		promotion := ""
+		if move.PromotedToFigure != "" {
+			promotion = SANPromotionSign + strings.ToUpper(move.PromotedToFigure)
+		}
+		return fmt.Sprintf("%s%s%s%s", origin, capture, destination, promotion)
+	}
+
+	return fmt.Sprintf("%s%s%s%s", strings.ToUpper(move.Figure),
+		disambiguation(board, move, figureRow, figureCol, destRow, destCol, isWhite), capture, destination)
+}
+
+// The origin file, rank or both are added when another figure of the same type can also legally move to the
+// destination tile, preferring the file over the rank
+func disambiguation(board *Board, move *Move, figureRow int, figureCol int, destRow int, destCol int,
+	isWhite bool) string {
+	if IsFigureType(move.Figure, King) {
+		return ""
+	}
+
+	ambiguous, sameFile, sameRank := false, false, false
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if (i == figureRow && j == figureCol) || board[i][j] != ColoredFigure(move.Figure, isWhite) {
+				continue
+			}
+			if validateFigureMove(board, move, i, j, destRow, destCol, isWhite) != nil ||
+				willKingBeInCheck(board, i, j, destRow, destCol, isWhite) {
+				continue
+			}
+			ambiguous = true
+			sameFile = sameFile || j == figureCol
+			sameRank = sameRank || i == figureRow
+		}
+	}
+
+	if !ambiguous {
+		return ""
+	}
+	if !sameFile {
+		return move.FigureFile
+	}
+	if !sameRank {
+		return move.FigureRank
+	}
+	return move.FigureFile + move.FigureRank
+}
+
+var (
+	sanFigureMoveRegex = regexp.MustCompile(
+		fmt.Sprintf("^([NBRQK])([a-h])?([1-8])?(%s)?([a-h])([1-8])([%s%s])?$", CaptureSign, KingCheckSign, CheckmateSign))
+	sanPawnMoveRegex = regexp.MustCompile(
+		fmt.Sprintf("^(?:([a-h])(%s))?([a-h])([1-8])(?:%s?([NBRQ]))?([%s%s])?$", CaptureSign, SANPromotionSign,
+			KingCheckSign, CheckmateSign))
+	sanDropMoveRegex = regexp.MustCompile(
+		fmt.Sprintf("^([PNBRQ])?%s([a-h])([1-8])([%s%s])?$", DropSign, KingCheckSign, CheckmateSign))
+)
+
+// SANToMove godoc
+// Converts the move in Standard Algebraic Notation to the format accepted by MakeMove, which contains the position
+// of the figure making the move (e.g. Nbd2 -> Nb1d2, exd5 -> Pe4xd5, e8=Q -> Pe7e8Q, O-O -> 0-0, @e4 -> P@e4). The
+// annotation suffixes such as ! or ?? are ignored.
+func (g *Game) SANToMove(san string, isWhite bool) (string, error) {
+	m, err := parseSANMove(strings.TrimRight(san, "!?"))
+	if err != nil {
+		return "", err
+	}
+
+	if g.Position().Variant != nil {
+		err = g.resolveVariantMove(m)
+	} else {
+		err = g.resolveFigurePosition(m, isWhite)
+	}
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("%s: %s", err.Error(), san))
+	}
+
+	return m.String(), nil
+}
+
+// LastMoveSAN returns the last move played in the game in Standard Algebraic Notation
+func (g *Game) LastMoveSAN() string {
+	return g.lastMoveSAN
+}
+
+func parseSANMove(san string) (*Move, error) {
+	castling := strings.TrimRight(san, KingCheckSign+CheckmateSign)
+	if castling == SANKingSideCastlingMove {
+		return &Move{IsKingSideCastling: true}, nil
+	}
+	if castling == SANQueenSideCastlingMove {
+		return &Move{IsQueenSideCastling: true}, nil
+	}
+
+	// 1 -> figure, 2 -> figure file, 3 -> figure rank, 4 -> capture char, 5 -> dest file, 6 -> dest rank,
+	// 7 -> king check or checkmate mark
+	if matches := sanFigureMoveRegex.FindStringSubmatch(san); matches != nil {
+		return &Move{Figure: matches[1], FigureFile: matches[2], FigureRank: matches[3], DestinationFile: matches[5],
+			DestinationRank: matches[6], IsCapture: matches[4] == CaptureSign, IsKingCheck: matches[7] != ""}, nil
+	}
+
+	// 1 -> figure file, 2 -> capture char, 3 -> dest file, 4 -> dest rank, 5 -> promoted figure,
+	// 6 -> king check or checkmate mark
+	if matches := sanPawnMoveRegex.FindStringSubmatch(san); matches != nil {
+		return &Move{Figure: Pawn, FigureFile: matches[1], DestinationFile: matches[3], DestinationRank: matches[4],
+			PromotedToFigure: matches[5], IsCapture: matches[2] == CaptureSign, IsKingCheck: matches[6] != ""}, nil
+	}
+
+	// 1 -> figure, which is the pawn if omitted, 2 -> dest file, 3 -> dest rank, 4 -> king check or checkmate mark
+	if matches := sanDropMoveRegex.FindStringSubmatch(san); matches != nil {
+		figure := matches[1]
+		if figure == "" {
+			figure = Pawn
+		}
+		return &Move{Figure: figure, DestinationFile: matches[2], DestinationRank: matches[3], IsDrop: true,
+			IsKingCheck: matches[4] != ""}, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("invalid SAN move format: %s", san))
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSANMoves(t *testing.T) {
+	moves, err := MakeSANMoves(StartingFEN, []string{"Pe2e4", "pe7e5", "Ng1f3", "nb8c6", "Bf1b5", "pa7a6", "Bb5xc6",
+		"pd7xc6", "0-0", DrawOfferMove, DrawOfferRejectMove, "bc8g4"})
+	utils.AssertTestCondition(t, nil, err, "Moves should be converted without error")
+	utils.AssertTestCondition(t, "e4 e5 Nf3 Nc6 Bb5 a6 Bxc6 dxc6 O-O Bg4", strings.Join(moves, " "),
+		"Moves should be converted to SAN")
+}
+
+func TestSANCheckmateMove(t *testing.T) {
+	moves, _ := MakeSANMoves(StartingFEN, []string{"Pf2f3", "pe7e5", "Pg2g4", "qd8h4#"})
+	utils.AssertTestCondition(t, "f3 e5 g4 Qh4#", strings.Join(moves, " "), "Checkmate should be marked")
+}
+
+func TestSANEnPassantMove(t *testing.T) {
+	moves, _ := MakeSANMoves(StartingFEN, []string{"Pe2e4", "pa7a6", "Pe4e5", "pd7d5", "Pe5xd6e.p."})
+	utils.AssertTestCondition(t, "exd6", moves[len(moves)-1], "En passant capture should be converted")
+}
+
+func TestSANPromotionMove(t *testing.T) {
+	moves, _ := MakeSANMoves("4k3/3P4/8/8/8/8/8/4K3 w - - 0 1", []string{"Pd7d8Q"})
+	utils.AssertTestCondition(t, "d8=Q+", moves[0], "Promotion should be converted")
+}
+
+func TestSANFileDisambiguation(t *testing.T) {
+	moves, _ := MakeSANMoves("4k3/8/8/8/8/5N2/8/1N2K3 w - - 0 1", []string{"Nb1d2"})
+	utils.AssertTestCondition(t, "Nbd2", moves[0], "Move should be disambiguated by file")
+}
+
+func TestSANRankDisambiguation(t *testing.T) {
+	moves, _ := MakeSANMoves("4k3/8/8/R7/8/8/8/R3K3 w - - 0 1", []string{"Ra1a3"})
+	utils.AssertTestCondition(t, "R1a3", moves[0], "Move should be disambiguated by rank")
+}
+
+func TestSANFileAndRankDisambiguation(t *testing.T) {
+	moves, _ := MakeSANMoves("7k/8/8/8/4Q2Q/8/8/K6Q w - - 0 1", []string{"Qh4e1"})
+	utils.AssertTestCondition(t, "Qh4e1+", moves[0], "Move should be disambiguated by file and rank")
+}
+
+func TestSANPinnedFigureDisambiguation(t *testing.T) {
+	moves, _ := MakeSANMoves("4k3/8/8/b7/8/2N3N1/8/4K3 w - - 0 1", []string{"Ng3e2"})
+	utils.AssertTestCondition(t, "Ne2", moves[0], "Pinned figure should not require disambiguation")
+}
+
+func TestSANGameMoves(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	sanMoves := []string{"e4", "e5", "Nf3", "Nc6", "Bb5", "a6", "Bxc6", "dxc6", "O-O", "Bg4"}
+	normalized := make([]string, 0)
+	played := make([]string, 0)
+	for i, m := range sanMoves {
+		move, _, err := g.MakeMove(m, i%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "SAN move should be played without error: "+m)
+		normalized = append(normalized, move)
+		played = append(played, g.LastMoveSAN())
+	}
+
+	utils.AssertTestCondition(t, "Pe2e4 pe7e5 Ng1f3 nb8c6 Bf1b5 pa7a6 Bb5xc6 pd7xc6 0-0 bc8g4",
+		strings.Join(normalized, " "), "SAN moves should be normalized")
+	utils.AssertTestCondition(t, strings.Join(sanMoves, " "), strings.Join(played, " "),
+		"Played moves should be converted back to SAN")
+}
+
+func TestSANAmbiguousMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/8/8/5N2/8/1N2K3 w - - 0 1", []string{})
+	_, _, err := g.MakeMove("Nd2", true)
+	utils.AssertTestCondition(t, true, err != nil, "Ambiguous move should be rejected")
+
+	move, _, err := g.MakeMove("Nbd2", true)
+	utils.AssertTestCondition(t, nil, err, "Disambiguated move should be played without error")
+	utils.AssertTestCondition(t, "Nb1d2", move, "Disambiguated move should be normalized")
+}
+
+func TestSANPinnedFigureMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/b7/8/2N3N1/8/4K3 w - - 0 1", []string{})
+	move, _, err := g.MakeMove("Ne2", true)
+	utils.AssertTestCondition(t, nil, err, "Move should be resolved to the figure which is not pinned")
+	utils.AssertTestCondition(t, "Ng3e2", move, "Move should be normalized")
+}
+
+func TestSANPromotionGameMove(t *testing.T) {
+	for _, m := range []string{"d8=N", "d8N"} {
+		g, _ := MakeGameFromFEN("4k3/3P4/8/8/8/8/8/4K3 w - - 0 1", []string{})
+		move, _, err := g.MakeMove(m, true)
+		utils.AssertTestCondition(t, nil, err, "Promotion should be played without error: "+m)
+		utils.AssertTestCondition(t, "Pd7d8N", move, "Promotion should be normalized")
+		utils.AssertTestCondition(t, "d8=N", g.LastMoveSAN(), "Promotion should be converted to SAN")
+	}
+}
+
+func TestSANPawnCaptureGameMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/8/8/2n5/1P6/4K3 w - - 0 1", []string{})
+	move, _, err := g.MakeMove("bxc3", true)
+	utils.AssertTestCondition(t, nil, err, "Pawn capture should be played without error")
+	utils.AssertTestCondition(t, "Pb2xc3", move, "Pawn capture should be normalized")
+}
+
+func TestLegacyMoveResolution(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	move, _, err := g.MakeMove("Nc3", true)
+	utils.AssertTestCondition(t, nil, err, "Legacy move should be resolved to the only figure which can make it")
+	utils.AssertTestCondition(t, "Nb1c3", move, "Legacy move should be normalized")
+
+	g, _ = MakeGameFromFEN("r6k/8/8/8/8/4K3/8/r7 b - - 0 1", []string{})
+	move, _, err = g.MakeMove("rab1", false)
+	utils.AssertTestCondition(t, nil, err, "Legacy move should be resolved to the figure which can make it")
+	utils.AssertTestCondition(t, "ra1b1", move, "Legacy move should be normalized")
+}
+
+func TestSANToMove(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{"Pe2e4", "pd7d5"})
+	move, err := g.SANToMove("exd5!?", true)
+	utils.AssertTestCondition(t, nil, err, "SAN move should be converted without error")
+	utils.AssertTestCondition(t, "Pe4xd5", move, "SAN move should be converted to legacy format")
+
+	_, err = g.SANToMove("Pe4xd5", true)
+	utils.AssertTestCondition(t, true, err != nil, "Legacy move should not be accepted as SAN")
+}
+
+func TestLegalMoveToSAN(t *testing.T) {
+	p, _ := ParseFEN("rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq - 0 2")
+	san := make([]string, 0)
+	for _, m := range LegalMoves(p) {
+		san = append(san, p.MoveToSAN(&m))
+	}
+	utils.AssertTestCondition(t, true, slices.Contains(san, "Qh4#"), "Checkmate should be marked in SAN")
+	utils.AssertTestCondition(t, true, slices.Contains(san, "Nc6"), "Legal move should be converted to SAN")
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// MakePositionFromTiles creates the custom position from the tiles of the board and the player on turn, where the
+// castling rights are kept for the kings and rooks which stand on their starting tiles
+func MakePositionFromTiles(tiles string, isWhiteTurn bool) (*Position, error) {
+	board, err := parseTiles(tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	p := Position{IsWhiteTurn: isWhiteTurn, CastlingRights: AllCastlingRights, EnPassantTile: NoEnPassantTile,
+		FullMoveNumber: 1}
+	p.settle(board, nil)
+	p.Hash = p.zobristHash()
+	return &p, nil
+}
+
+// SetUpVariantPosition prepares the custom position to start the game of the variant from it, which must be the
+// position where the game can be played by the rules of the variant
+func SetUpVariantPosition(p *Position, variant string) error {
+	if p.Variant != nil && variant != CrazyhouseVariant {
+		return errors.New("only the position of Crazyhouse can have the pocket")
+	}
+	p.Variant = FindVariant(variant)
+
+	return ValidatePosition(p)
+}
+
+// ValidatePosition checks whether the game can be played from the position. Each player must have exactly one king,
+// at most 8 pawns and 16 figures, the pawns cannot stand on the first or the last rank, the player who is not on turn
+// cannot be in check and the player on turn must have a legal move in the game which is not decided yet.
+func ValidatePosition(p *Position) error {
+	bp := makeBitboardPosition(p)
+	for side := whiteSide; side <= blackSide; side++ {
+		player := "white"
+		if side == blackSide {
+			player = "black"
+		}
+		if bits.OnesCount64(bp.figures[side][kingType]) != 1 {
+			return errors.New(fmt.Sprintf("the %s player must have exactly one king", player))
+		}
+		if bits.OnesCount64(bp.figures[side][pawnType]) > 8 {
+			return errors.New(fmt.Sprintf("the %s player cannot have more than 8 pawns", player))
+		}
+		if bits.OnesCount64(bp.occupied[side]) > 16 {
+			return errors.New(fmt.Sprintf("the %s player cannot have more than 16 figures", player))
+		}
+	}
+
+	if (bp.figures[whiteSide][pawnType]|bp.figures[blackSide][pawnType])&backRanks != 0 {
+		return errors.New("pawns cannot stand on the first or the last rank")
+	}
+
+	if bp.rules.isInCheck(&bp, 1-bp.side) {
+		return errors.New("the player who is not on turn cannot be in check")
+	}
+
+	if bp.rules.outcome(&bp) != NoOutcome {
+		return errors.New(fmt.Sprintf("the game is already decided by the rules of %s", p.Variant.Title()))
+	}
+
+	if len(bp.legalMoves(nil)) == 0 {
+		return errors.New("the player on turn must have a legal move")
+	}
+
+	return nil
+}
+
+// IsWhiteFirstOnTurn checks whether the white player makes the first move of the game, which is not the case only in
+// the games started from the custom position in Forsyth-Edwards Notation with the black player on turn
+func IsWhiteFirstOnTurn(startingFen string) bool {
+	fields := strings.Fields(startingFen)
+	return len(fields) < 2 || fields[1] != "b"
+}
+
+// ReplayVariantGameFromFEN creates the game of the variant from the custom starting position in Forsyth-Edwards
+// Notation by replaying all moves played from it. The moves must be in normalized format.
+func ReplayVariantGameFromFEN(variant string, fen string, moves []string) (*Game, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	p.Variant = FindVariant(variant)
+
+	return replayGame(*p, moves)
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+func validateFEN(t *testing.T, fen string, variant string) error {
+	p, err := ParseFEN(fen)
+	utils.AssertTestCondition(t, nil, err, "FEN should be valid: "+fen)
+	return SetUpVariantPosition(p, variant)
+}
+
+func TestValidatePosition(t *testing.T) {
+	err := validateFEN(t, "8/8/4k3/8/8/4K3/4P3/8 b - - 0 1", StandardVariant)
+	utils.AssertTestCondition(t, nil, err, "Endgame position should be valid")
+}
+
+func TestValidateInvalidPositions(t *testing.T) {
+	for _, fen := range []string{
+		"4k3/8/8/8/8/8/8/r3K2R b K - 0 1",
+		"4k3/8/8/8/8/8/8/4K2P w - - 0 1",
+		"7k/5Q2/6K1/8/8/8/8/8 b - - 0 1",
+		"7k/6Q1/6K1/8/8/8/8/8 b - - 0 1",
+		"4k3/pppppppp/p7/8/8/8/8/4K3 w - - 0 1",
+	} {
+		err := validateFEN(t, fen, StandardVariant)
+		utils.AssertTestCondition(t, true, err != nil, "Position should be invalid: "+fen)
+	}
+}
+
+func TestValidateVariantPosition(t *testing.T) {
+	err := validateFEN(t, "8/8/8/3K4/8/8/8/7k b - - 0 1", KingOfTheHillVariant)
+	utils.AssertTestCondition(t, true, err != nil, "Decided game should be invalid")
+
+	err = validateFEN(t, "8/8/8/3K4/8/8/8/7k[] b - - 0 1", StandardVariant)
+	utils.AssertTestCondition(t, true, err != nil, "Pocket should be allowed only in Crazyhouse")
+}
+
+func TestMakePositionFromTiles(t *testing.T) {
+	tiles := "0000k000" + strings.Repeat("0", 48) + "R000K00R"
+	p, err := MakePositionFromTiles(tiles, false)
+	utils.AssertTestCondition(t, nil, err, "Tiles should be valid")
+	utils.AssertTestCondition(t, "4k3/8/8/8/8/8/8/R3K2R b KQ - 0 1", p.FEN(), "Position should have black on turn")
+
+	utils.AssertTestCondition(t, nil, ValidatePosition(p), "Position from tiles should be valid")
+
+	p, err = MakePositionFromTiles(strings.Repeat("0", 64), true)
+	utils.AssertTestCondition(t, nil, err, "Empty tiles should be parsed")
+	utils.AssertTestCondition(t, true, ValidatePosition(p) != nil, "Position without kings should be invalid")
+}
+
+func TestIsWhiteFirstOnTurn(t *testing.T) {
+	utils.AssertTestCondition(t, true, IsWhiteFirstOnTurn(""), "White player should be first in standard game")
+	utils.AssertTestCondition(t, false, IsWhiteFirstOnTurn("8/8/4k3/8/8/4K3/4P3/8 b - - 0 1"),
+		"Black player should be first when on turn in custom position")
+}
+
+func TestReplayGameFromFEN(t *testing.T) {
+	fen := "8/8/4k3/8/8/4K3/4P3/8 b - - 0 1"
+	g, err := ReplayVariantGameFromFEN(StandardVariant, fen, []string{"ke6d5", "Ke3d3", "kd5e5"})
+	utils.AssertTestCondition(t, nil, err, "Moves should be replayed from the custom position")
+	utils.AssertTestCondition(t, "8/8/8/4k3/8/3K4/4P3/8 w - - 3 3", g.FEN(), "Game should reach the position")
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	SANMoveFormat = "san"
+	UCIMoveFormat = "uci"
+)
+
+var (
+	uciMoveRegex     = regexp.MustCompile("^([a-h])([1-8])([a-h])([1-8])([qrbn])?$")
+	uciDropMoveRegex = regexp.MustCompile(fmt.Sprintf("^([PNBRQ])%s([a-h][1-8])$", DropSign))
+)
+
+// UCIToMove godoc
+// Converts the move in long algebraic notation used by Universal Chess Interface engines to the format accepted by
+// MakeMove (e.g. e2e4 -> Pe2e4, e7e8q -> Pe7e8Q, e1g1 -> 0-0, or f1d1 -> 0-0-0 in Chess960). The figure is taken from
+// the origin tile of the move. The drop in Crazyhouse is written with the uppercase figure for both players
+// (e.g. N@f3).
+func (g *Game) UCIToMove(uci string, isWhite bool) (string, error) {
+	// 1 -> figure, 2 -> dest tile
+	if drop := uciDropMoveRegex.FindStringSubmatch(uci); drop != nil {
+		return fmt.Sprintf("%s%s%s", ColoredFigure(drop[1], isWhite), DropSign, drop[2]), nil
+	}
+
+	// 1 -> figure file, 2 -> figure rank, 3 -> dest file, 4 -> dest rank, 5 -> promoted figure
+	matches := uciMoveRegex.FindStringSubmatch(uci)
+	if matches == nil {
+		return "", errors.New(fmt.Sprintf("invalid UCI move format: %s", uci))
+	}
+
+	figureRow, figureCol := BoardRankToRow(matches[2]), BoardFileToColumn(matches[1])
+	destCol := BoardFileToColumn(matches[3])
+	figure := g.Board[figureRow][figureCol]
+	if figure == Empty || !IsPlayersFigure(figure, isWhite) {
+		return "", errors.New(fmt.Sprintf("no players figure on the origin tile of the move: %s", uci))
+	}
+
+	homeRow := 0
+	if isWhite {
+		homeRow = 7
+	}
+	if IsFigureType(figure, King) && figureRow == homeRow && figureCol == 4 &&
+		matches[2] == matches[4] && (destCol == 6 || destCol == 2) {
+		if destCol == 6 {
+			return KingSideCastligMove, nil
+		}
+		return QueenSideCastligMove, nil
+	}
+	// The castling in Chess960 is written as the king capturing its own rook
+	if IsFigureType(figure, King) && matches[2] == matches[4] &&
+		g.Board[figureRow][destCol] == ColoredFigure(Rook, isWhite) {
+		if destCol > figureCol {
+			return KingSideCastligMove, nil
+		}
+		return QueenSideCastligMove, nil
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s%s", strings.ToUpper(figure), matches[1], matches[2], matches[3], matches[4],
+		strings.ToUpper(matches[5])), nil
+}
+
+// MoveToUCI godoc
+// Converts the normalized move to long algebraic notation used by Universal Chess Interface engines
+// (e.g. Pe2e4 -> e2e4, Pe7e8Q -> e7e8q, 0-0 -> e1g1). The castling is written as the king moving from its origin tile
+// to the g or c file with the standard castling files, or as the king capturing its own rook in Chess960 with other
+// castling files (e.g. 0-0-0 -> f1d1), where the empty castling files are the standard ones. The draw offers and
+// claims are returned unchanged.
+func MoveToUCI(move string, isWhite bool, files CastlingFiles) (string, error) {
+	if IsDrawMove(move) {
+		return move, nil
+	}
+
+	m, err := parseMove(move)
+	if err != nil {
+		return "", err
+	}
+
+	if m.IsKingSideCastling || m.IsQueenSideCastling {
+		return castlingToUCI(m.IsKingSideCastling, isWhite, files), nil
+	}
+	if m.IsDrop {
+		return fmt.Sprintf("%s%s%s%s", strings.ToUpper(m.Figure), DropSign, m.DestinationFile, m.DestinationRank), nil
+	}
+
+	if m.FigureFile == "" || m.FigureRank == "" {
+		return "", errors.New(fmt.Sprintf("cannot convert move without figure position: %s", move))
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s", m.FigureFile, m.FigureRank, m.DestinationFile, m.DestinationRank,
+		strings.ToLower(m.PromotedToFigure)), nil
+}
+
+func castlingToUCI(isKingSide bool, isWhite bool, files CastlingFiles) string {
+	rank := "8"
+	if isWhite {
+		rank = "1"
+	}
+
+	if files == (CastlingFiles{}) || files == StandardCastlingFiles {
+		destFile := "c"
+		if isKingSide {
+			destFile = "g"
+		}
+		return fmt.Sprintf("%s%s%s%s", StandardCastlingFiles.King, rank, destFile, rank)
+	}
+
+	rookFile := files.QueenSideRook
+	if isKingSide {
+		rookFile = files.KingSideRook
+	}
+	return fmt.Sprintf("%s%s%s%s", files.King, rank, rookFile, rank)
+}
+
+// MakeUCIMoves converts the normalized moves played in turns from the position in Forsyth-Edwards Notation to long
+// algebraic notation, where the draw offers and claims are kept unchanged and do not change the side to move. The
+// castling is converted by the castling files of the position.
+func MakeUCIMoves(fen string, moves []string) ([]string, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+
+	uciMoves := make([]string, 0)
+	isWhite := p.IsWhiteTurn
+	for _, m := range moves {
+		uci, e := MoveToUCI(m, isWhite, p.CastlingFiles)
+		if e != nil {
+			return nil, e
+		}
+		uciMoves = append(uciMoves, uci)
+		if !IsDrawMove(m) {
+			isWhite = !isWhite
+		}
+	}
+
+	return uciMoves, nil
+}
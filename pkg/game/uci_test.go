@@ -0,0 +1,99 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+func TestUCIGameMoves(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	uciMoves := []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "e1g1", "f8c5"}
+	normalized := make([]string, 0)
+	for i, m := range uciMoves {
+		move, err := g.UCIToMove(m, i%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "UCI move should be converted without error: "+m)
+		move, _, err = g.MakeMove(move, i%2 == 0)
+		utils.AssertTestCondition(t, nil, err, "UCI move should be played without error: "+m)
+		normalized = append(normalized, move)
+	}
+
+	utils.AssertTestCondition(t, "Pe2e4 pe7e5 Ng1f3 nb8c6 Bf1c4 ng8f6 0-0 bf8c5", strings.Join(normalized, " "),
+		"UCI moves should be normalized")
+
+	converted, err := MakeUCIMoves(StartingFEN, normalized)
+	utils.AssertTestCondition(t, nil, err, "Moves should be converted to UCI without error")
+	utils.AssertTestCondition(t, strings.Join(uciMoves, " "), strings.Join(converted, " "),
+		"Normalized moves should be converted back to UCI")
+}
+
+func TestUCIPromotionMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("4k3/8/8/8/8/8/1p6/4K3 b - - 0 1", []string{})
+	move, err := g.UCIToMove("b2b1n", false)
+	utils.AssertTestCondition(t, nil, err, "UCI promotion should be converted without error")
+	utils.AssertTestCondition(t, "Pb2b1N", move, "UCI promotion should be converted")
+
+	move, _, _ = g.MakeMove(move, false)
+	uci, _ := MoveToUCI(move, false, StandardCastlingFiles)
+	utils.AssertTestCondition(t, "b2b1n", uci, "Promotion should be converted to UCI")
+}
+
+func TestUCIBlackCastlingMove(t *testing.T) {
+	g, _ := MakeGameFromFEN("r3k3/8/8/8/8/8/8/4K3 b q - 0 1", []string{})
+	move, err := g.UCIToMove("e8c8", false)
+	utils.AssertTestCondition(t, nil, err, "UCI castling should be converted without error")
+	utils.AssertTestCondition(t, QueenSideCastligMove, move, "UCI castling should be converted")
+
+	uci, _ := MoveToUCI(QueenSideCastligMove, false, StandardCastlingFiles)
+	utils.AssertTestCondition(t, "e8c8", uci, "Castling should be converted to UCI")
+}
+
+func TestUCIChess960CastlingMove(t *testing.T) {
+	g, _ := ReplayChess960Game(200, []string{"Nc1b3", "pa7a6", "Pd2d3", "pa6a5", "Be1d2", "pa5a4"})
+	move, err := g.UCIToMove("f1d1", true)
+	utils.AssertTestCondition(t, nil, err, "UCI Chess960 castling should be converted without error")
+	utils.AssertTestCondition(t, QueenSideCastligMove, move, "King capturing its own rook should be castling")
+
+	_, _, err = g.MakeMove(move, true)
+	utils.AssertTestCondition(t, nil, err, "Converted Chess960 castling should be valid")
+
+	uci, err := MoveToUCI(move, true, g.Position().CastlingFiles)
+	utils.AssertTestCondition(t, nil, err, "Chess960 castling should be converted to UCI without error")
+	utils.AssertTestCondition(t, "f1d1", uci, "Chess960 castling should be converted to king capturing its rook")
+}
+
+func TestUCIChess960CastlingRoundTrip(t *testing.T) {
+	start, _ := ReplayChess960Game(200, []string{})
+	moves := []string{"Nc1b3", "pa7a6", "Pd2d3", "pa6a5", "Be1d2", "pa5a4", QueenSideCastligMove}
+	uciMoves, err := MakeUCIMoves(start.FEN(), moves)
+	utils.AssertTestCondition(t, nil, err, "Chess960 moves should be converted to UCI without error")
+	utils.AssertTestCondition(t, "c1b3 a7a6 d2d3 a6a5 e1d2 a5a4 f1d1", strings.Join(uciMoves, " "),
+		"Chess960 moves should be converted to UCI")
+
+	g, _ := ReplayChess960Game(200, []string{})
+	normalized := make([]string, 0)
+	for i, uci := range uciMoves {
+		move, e := g.UCIToMove(uci, i%2 == 0)
+		utils.AssertTestCondition(t, nil, e, "UCI move should be converted without error: "+uci)
+		move, _, e = g.MakeMove(move, i%2 == 0)
+		utils.AssertTestCondition(t, nil, e, "UCI move should be played without error: "+uci)
+		normalized = append(normalized, move)
+	}
+	utils.AssertTestCondition(t, strings.Join(moves, " "), strings.Join(normalized, " "),
+		"UCI moves should be converted back to the same moves")
+}
+
+func TestUCIDrawTokens(t *testing.T) {
+	moves, err := MakeUCIMoves(StartingFEN, []string{"Pe2e4", DrawOfferMove, DrawOfferRejectMove, "pe7e5", "0-0"})
+	utils.AssertTestCondition(t, nil, err, "Moves should be converted to UCI without error")
+	utils.AssertTestCondition(t, "e2e4 = ! e7e5 e1g1", strings.Join(moves, " "),
+		"Draw tokens should be kept and not change the side to move")
+}
+
+func TestInvalidUCIMove(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	for _, m := range []string{"e2e9", "e2", "0000", "e2e4Q", "Pe2e4", "e7e5", "e3e4"} {
+		_, err := g.UCIToMove(m, true)
+		utils.AssertTestCondition(t, true, err != nil, "Invalid UCI move should be rejected: "+m)
+	}
+}
@@ -0,0 +1,334 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+const (
+	StandardVariant      = "standard"
+	Chess960Variant      = "chess960"
+	KingOfTheHillVariant = "kingofthehill"
+	ThreeCheckVariant    = "threecheck"
+	AtomicVariant        = "atomic"
+	CrazyhouseVariant    = "crazyhouse"
+)
+
+const (
+	KingOfTheHillOutcome Outcome = "king of the hill"
+	ThreeCheckOutcome    Outcome = "three checks"
+	ExplosionOutcome     Outcome = "king explosion"
+)
+
+// ThreeCheckCount is the number of checks which wins the game of Three-check
+const ThreeCheckCount = 3
+
+// The tiles d5, e5, d4 and e4 in the center of the board, which the king must reach in King of the Hill
+const hillTiles = uint64(1)<<27 | uint64(1)<<28 | uint64(1)<<35 | uint64(1)<<36
+
+// The first and the last rank, where the pawns cannot be dropped in Crazyhouse
+const backRanks = uint64(0xff) | uint64(0xff)<<56
+
+// The order of the figures in the pocket, where the white figures are written before the black ones
+var pocketFigureTypes = []int{queenType, rookType, bishopType, knightType, pawnType}
+
+// Variant godoc
+// The rules of the chess variant which differ from standard chess. The variant can decide the game by its own win
+// condition, restrict the moves which are legal in standard chess and change the position after the move is played.
+// The standard chess and Chess960 have no variant rules, since they differ only in the starting position.
+type Variant interface {
+	// Name returns the name of the variant stored on the game (e.g. kingofthehill)
+	Name() string
+	// Title returns the name of the variant shown to the players and written in the Variant tag of PGN
+	Title() string
+	// outcome returns the outcome by which the variant has decided the game in the position, which is always lost by
+	// the player on turn, or NoOutcome
+	outcome(p *bitboardPosition) Outcome
+	// isLegal checks whether the pseudo legal move of the player on turn is legal in the position after it
+	isLegal(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) bool
+	// isInCheck checks whether the king of the side is in check in the position
+	isInCheck(p *bitboardPosition, side int) bool
+	// afterMove applies the effects of the move to the position after it, which has already been played
+	afterMove(p *bitboardPosition, move *bitboardMove, next *bitboardPosition)
+}
+
+// standardRules are embedded in the variants, which override only the rules they change
+type standardRules struct{}
+
+type kingOfTheHill struct{ standardRules }
+
+type threeCheck struct{ standardRules }
+
+type atomic struct{ standardRules }
+
+type crazyhouse struct{ standardRules }
+
+var variants = map[string]Variant{
+	KingOfTheHillVariant: kingOfTheHill{},
+	ThreeCheckVariant:    threeCheck{},
+	AtomicVariant:        atomic{},
+	CrazyhouseVariant:    crazyhouse{},
+}
+
+// IsValidVariant checks whether the game variant is supported
+func IsValidVariant(variant string) bool {
+	return variant == StandardVariant || variant == Chess960Variant || FindVariant(variant) != nil
+}
+
+// FindVariant returns the rules of the variant, or nil for standard chess and Chess960
+func FindVariant(variant string) Variant {
+	return variants[variant]
+}
+
+// ReplayVariantGame creates the game of the variant by replaying all moves played so far, where the Chess960 game
+// starts from the position with the number and the other variants from the standard starting position. The moves must
+// be in normalized format.
+func ReplayVariantGame(variant string, startingPositionId int, moves []string) (*Game, error) {
+	if variant != Chess960Variant {
+		startingPositionId = StandardStartingPositionId
+	}
+
+	p, err := Chess960StartingPosition(startingPositionId)
+	if err != nil {
+		return nil, err
+	}
+	p.Variant = FindVariant(variant)
+
+	return replayGame(*p, moves)
+}
+
+// VariantOutcome returns the outcome by which the variant has decided the game in the position, which is always lost
+// by the player on turn, or NoOutcome
+func (p *Position) VariantOutcome() Outcome {
+	if p.Variant == nil {
+		return NoOutcome
+	}
+	bp := makeBitboardPosition(p)
+	return p.Variant.outcome(&bp)
+}
+
+// IsCheck checks whether the player on turn is in check by the rules of the variant
+func (p *Position) IsCheck() bool {
+	bp := makeBitboardPosition(p)
+	return bp.rules.isInCheck(&bp, bp.side)
+}
+
+// The moves of the variant are played on the bitboards, which apply all its effects on the position after the move
+func (p *Position) playVariantMove(move *Move) Position {
+	bp := makeBitboardPosition(p)
+	m := bp.fromMove(move)
+	next := bp.play(&m)
+	board := next.board()
+
+	n := p.advance(move)
+	n.settle(&board, move)
+	n.Pocket, n.Checks, n.promotedTiles = pocketString(next.pocket), next.checks, next.promoted
+	// The figures removed by the effects of the variant (e.g. the explosion in Atomic) are hashed together with the move
+	n.Hash = p.nextHash(&n, p.moveTiles(move)|(bp.all()^next.all()))
+	return n
+}
+
+// The moves of the variant are validated against its legal moves, since the validation of the figure moves on the
+// board knows only the rules of standard chess
+func (g *Game) resolveVariantMove(move *Move) error {
+	candidates := make([]Move, 0)
+	for _, m := range g.LegalMoves() {
+		if m.IsKingSideCastling != move.IsKingSideCastling || m.IsQueenSideCastling != move.IsQueenSideCastling ||
+			m.IsDrop != move.IsDrop {
+			continue
+		}
+		if !m.IsKingSideCastling && !m.IsQueenSideCastling && (!IsFigureType(m.Figure, move.Figure) ||
+			m.DestinationFile != move.DestinationFile || m.DestinationRank != move.DestinationRank ||
+			!IsFigureType(m.PromotedToFigure, move.PromotedToFigure) ||
+			(move.FigureFile != "" && m.FigureFile != move.FigureFile) ||
+			(move.FigureRank != "" && m.FigureRank != move.FigureRank)) {
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+
+	if len(candidates) == 0 {
+		if IsFigureType(move.Figure, Pawn) && !move.IsDrop && move.PromotedToFigure == "" && g.hasPromotionMove(move) {
+			return ErrPromotionRequired
+		}
+		return errors.New(fmt.Sprintf("the move is not legal by the rules of %s", g.Position().Variant.Title()))
+	}
+	if len(candidates) > 1 {
+		return errors.New("cannot uniquely identify figure on board")
+	}
+
+	*move = candidates[0]
+	return nil
+}
+
+func (standardRules) Name() string {
+	return StandardVariant
+}
+
+func (standardRules) Title() string {
+	return "Standard"
+}
+
+func (standardRules) outcome(p *bitboardPosition) Outcome {
+	return NoOutcome
+}
+
+func (standardRules) isLegal(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) bool {
+	return !next.isKingAttacked(p.side)
+}
+
+func (standardRules) isInCheck(p *bitboardPosition, side int) bool {
+	return p.isKingAttacked(side)
+}
+
+func (standardRules) afterMove(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) {}
+
+func (kingOfTheHill) Name() string {
+	return KingOfTheHillVariant
+}
+
+func (kingOfTheHill) Title() string {
+	return "King of the Hill"
+}
+
+// The player wins by moving the king to one of the tiles in the center of the board
+func (kingOfTheHill) outcome(p *bitboardPosition) Outcome {
+	if p.figures[1-p.side][kingType]&hillTiles != 0 {
+		return KingOfTheHillOutcome
+	}
+	return NoOutcome
+}
+
+func (threeCheck) Name() string {
+	return ThreeCheckVariant
+}
+
+func (threeCheck) Title() string {
+	return "Three-check"
+}
+
+// The player wins by giving the check to the opponent for the third time
+func (threeCheck) outcome(p *bitboardPosition) Outcome {
+	if p.checks[1-p.side] >= ThreeCheckCount {
+		return ThreeCheckOutcome
+	}
+	return NoOutcome
+}
+
+func (threeCheck) afterMove(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) {
+	if next.isKingAttacked(1 - p.side) {
+		next.checks[p.side]++
+	}
+}
+
+func (atomic) Name() string {
+	return AtomicVariant
+}
+
+func (atomic) Title() string {
+	return "Atomic"
+}
+
+// The player wins by exploding the king of the opponent
+func (atomic) outcome(p *bitboardPosition) Outcome {
+	if p.figures[p.side][kingType] == 0 {
+		return ExplosionOutcome
+	}
+	return NoOutcome
+}
+
+// The king cannot capture, since it would explode, and the move must not explode the own king. The move which explodes
+// the king of the opponent wins even if it leaves the own king in check.
+func (a atomic) isLegal(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) bool {
+	if move.figure == kingType && move.captured != noFigureType {
+		return false
+	}
+	if next.figures[p.side][kingType] == 0 {
+		return false
+	}
+	if next.figures[1-p.side][kingType] == 0 {
+		return true
+	}
+	return !a.isInCheck(next, p.side)
+}
+
+// The kings on adjacent tiles are never in check, since the king capturing the other one would explode with it
+func (atomic) isInCheck(p *bitboardPosition, side int) bool {
+	king, opponentKing := p.figures[side][kingType], p.figures[1-side][kingType]
+	if king != 0 && kingAttacks[bits.TrailingZeros64(king)]&opponentKing != 0 {
+		return false
+	}
+	return p.isKingAttacked(side)
+}
+
+// The capturing figure explodes together with all figures around the destination tile, except the pawns
+func (atomic) afterMove(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) {
+	if move.captured == noFigureType {
+		return
+	}
+
+	next.clear(p.side, next.figureAt(p.side, move.to), move.to)
+	for tiles := kingAttacks[move.to]; tiles != 0; tiles &= tiles - 1 {
+		tile := bits.TrailingZeros64(tiles)
+		for side := whiteSide; side <= blackSide; side++ {
+			if figureType := next.figureAt(side, tile); figureType != noFigureType && figureType != pawnType {
+				next.clear(side, figureType, tile)
+				next.castlingRights &^= p.castlingRightsLost(tile)
+			}
+		}
+	}
+}
+
+func (crazyhouse) Name() string {
+	return CrazyhouseVariant
+}
+
+func (crazyhouse) Title() string {
+	return "Crazyhouse"
+}
+
+// The captured figure goes to the pocket of the capturing player, where the promoted figure turns back into the pawn
+func (crazyhouse) afterMove(p *bitboardPosition, move *bitboardMove, next *bitboardPosition) {
+	to := uint64(1) << move.to
+	if move.captured != noFigureType {
+		figureType := move.captured
+		if p.promoted&to != 0 {
+			figureType = pawnType
+		}
+		next.pocket[p.side][figureType]++
+	}
+
+	next.promoted &^= to
+	if move.flags&dropFlag == 0 && p.promoted&(uint64(1)<<move.from) != 0 {
+		next.promoted = next.promoted&^(uint64(1)<<move.from) | to
+	}
+	if move.promotion != noFigureType {
+		next.promoted |= to
+	}
+}
+
+// The pocket is written with the white figures in uppercase followed by the black figures in lowercase, from the
+// queen to the pawn (e.g. QNPpp)
+func pocketString(pocket [2][6]int) string {
+	s := ""
+	for side := whiteSide; side <= blackSide; side++ {
+		for _, figureType := range pocketFigureTypes {
+			s += strings.Repeat(ColoredFigure(figureTypes[figureType], side == whiteSide), pocket[side][figureType])
+		}
+	}
+	return s
+}
+
+func parsePocket(s string) ([2][6]int, error) {
+	pocket := [2][6]int{}
+	for _, r := range s {
+		side, figureType := figureSideAndType(string(r))
+		if figureType == noFigureType || figureType == kingType {
+			return pocket, errors.New(fmt.Sprintf("invalid figure in pocket: %c", r))
+		}
+		pocket[side][figureType]++
+	}
+	return pocket, nil
+}
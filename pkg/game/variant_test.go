@@ -0,0 +1,153 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"strings"
+	"testing"
+)
+
+func playVariantMoves(t *testing.T, variant string, sanMoves string) (*Game, []string, Outcome) {
+	g, err := ReplayVariantGame(variant, StandardStartingPositionId, []string{})
+	utils.AssertTestCondition(t, nil, err, "Variant game should be created")
+
+	moves := make([]string, 0)
+	outcome := NoOutcome
+	for _, san := range strings.Fields(sanMoves) {
+		var move string
+		move, outcome, err = g.MakeMove(san, g.Position().IsWhiteTurn)
+		utils.AssertTestCondition(t, nil, err, "Move should be legal: "+san)
+		moves = append(moves, move)
+	}
+	return g, moves, outcome
+}
+
+func TestIsValidVariant(t *testing.T) {
+	for _, v := range []string{StandardVariant, Chess960Variant, KingOfTheHillVariant, ThreeCheckVariant,
+		AtomicVariant, CrazyhouseVariant} {
+		utils.AssertTestCondition(t, true, IsValidVariant(v), "Variant should be supported: "+v)
+	}
+	utils.AssertTestCondition(t, false, IsValidVariant("antichess"), "Unknown variant should not be supported")
+	utils.AssertTestCondition(t, true, FindVariant(Chess960Variant) == nil, "Chess960 should have standard rules")
+}
+
+func TestKingOfTheHillWin(t *testing.T) {
+	_, _, outcome := playVariantMoves(t, KingOfTheHillVariant, "e4 e6 Ke2 Ke7 Ke3 Kd6 Kd4")
+	utils.AssertTestCondition(t, KingOfTheHillOutcome, outcome, "King reaching the center should win")
+}
+
+func TestKingOfTheHillNoMovesAfterWin(t *testing.T) {
+	g, _, _ := playVariantMoves(t, KingOfTheHillVariant, "e4 e6 Ke2 Ke7 Ke3 Kd6 Kd4")
+	utils.AssertTestCondition(t, 0, len(g.LegalMoves()), "Decided game should have no legal moves")
+	utils.AssertTestCondition(t, false, g.Position().IsStalemate(), "Decided game should not be stalemate")
+}
+
+func TestThreeCheckWin(t *testing.T) {
+	g, _, outcome := playVariantMoves(t, ThreeCheckVariant, "e4 e5 Qh5 Nc6 Qxf7+ Kxf7 Bc4+ d5")
+	utils.AssertTestCondition(t, NoOutcome, outcome, "Two checks should not decide the game")
+	utils.AssertTestCondition(t, 2, g.Position().Checks[0], "White player should have given two checks")
+
+	_, outcome, err := g.MakeMove("Bxd5+", true)
+	utils.AssertTestCondition(t, nil, err, "Third check should be legal")
+	utils.AssertTestCondition(t, ThreeCheckOutcome, outcome, "Third check should win")
+}
+
+func TestAtomicExplosion(t *testing.T) {
+	g, _, outcome := playVariantMoves(t, AtomicVariant, "Nf3 a6 Ng5 a5 Nxf7")
+	utils.AssertTestCondition(t, ExplosionOutcome, outcome, "Capture next to the king should explode it")
+	utils.AssertTestCondition(t, Empty, g.Board[1][5], "Capturing knight should explode")
+	utils.AssertTestCondition(t, Empty, g.Board[0][6], "Figures around the capture should explode")
+	utils.AssertTestCondition(t, BlackFigure(Pawn), g.Board[1][6], "Pawns around the capture should not explode")
+	utils.AssertTestCondition(t, BlackFigure(Queen), g.Board[0][3], "Figures further away should not explode")
+}
+
+func TestAtomicKingCannotCapture(t *testing.T) {
+	p, err := ParseFEN("4k3/8/8/8/8/8/3p4/4K3 w - - 0 1")
+	utils.AssertTestCondition(t, nil, err, "FEN should be valid")
+	p.Variant = FindVariant(AtomicVariant)
+
+	moves := LegalMoves(p)
+	for _, m := range moves {
+		utils.AssertTestCondition(t, false, m.IsCapture, "King should not capture: "+m.String())
+	}
+	utils.AssertTestCondition(t, 4, len(moves), "King should escape the check of the pawn")
+}
+
+func TestAtomicAdjacentKingsAreNotInCheck(t *testing.T) {
+	p, err := ParseFEN("8/8/8/3k4/3K4/8/8/3r4 w - - 0 1")
+	utils.AssertTestCondition(t, nil, err, "FEN should be valid")
+	p.Variant = FindVariant(AtomicVariant)
+
+	utils.AssertTestCondition(t, false, p.IsCheck(), "King next to the opponent king should not be in check")
+}
+
+func TestCrazyhouseDrop(t *testing.T) {
+	g, moves, _ := playVariantMoves(t, CrazyhouseVariant, "e4 d5 exd5 Qxd5")
+	utils.AssertTestCondition(t, "Pp", g.Position().Pocket, "Captured pawns should go to the pockets")
+
+	_, _, err := g.MakeMove("P@d8", true)
+	utils.AssertTestCondition(t, true, err != nil, "Pawn should not be dropped on the last rank")
+
+	move, _, err := g.MakeMove("@e6", true)
+	utils.AssertTestCondition(t, nil, err, "Pawn should be dropped on the empty tile")
+	utils.AssertTestCondition(t, "P@e6", move, "Drop should be normalized")
+	utils.AssertTestCondition(t, WhiteFigure(Pawn), g.Board[2][4], "Dropped pawn should be on the board")
+
+	move, _, err = g.MakeMove("fxe6", false)
+	utils.AssertTestCondition(t, nil, err, "Dropped pawn should be captured")
+	utils.AssertTestCondition(t, "pp", g.Position().Pocket, "Captured pawn should change the side")
+
+	replayed, err := ReplayVariantGame(CrazyhouseVariant, 0, append(moves, "P@e6", move))
+	utils.AssertTestCondition(t, nil, err, "Game with drops should be replayed")
+	utils.AssertTestCondition(t, g.FEN(), replayed.FEN(), "Replayed game should reach the same position")
+}
+
+func TestCrazyhouseFEN(t *testing.T) {
+	fen := "rnb1kbnr/ppp1pppp/8/3q4/8/8/PPPP1PPP/RNBQKBNR[Pp] w KQkq - 0 3"
+	p, err := ParseFEN(fen)
+	utils.AssertTestCondition(t, nil, err, "FEN with pocket should be valid")
+	utils.AssertTestCondition(t, CrazyhouseVariant, p.Variant.Name(), "FEN with pocket should be Crazyhouse")
+	utils.AssertTestCondition(t, fen, p.FEN(), "FEN with pocket should be the same")
+
+	_, err = ParseFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[K] w KQkq - 0 1")
+	utils.AssertTestCondition(t, true, err != nil, "King should not be in the pocket")
+}
+
+func TestCrazyhouseCapturedPromotedFigure(t *testing.T) {
+	p, err := ParseFEN("4k3/1P6/8/8/8/8/8/r3K3[] w - - 0 1")
+	utils.AssertTestCondition(t, nil, err, "FEN should be valid")
+
+	next := p.Play(&Move{Figure: WhiteFigure(Pawn), FigureFile: "b", FigureRank: "7", DestinationFile: "b",
+		DestinationRank: "8", PromotedToFigure: Queen})
+	next = next.Play(&Move{Figure: BlackFigure(Rook), FigureFile: "a", FigureRank: "1", DestinationFile: "a",
+		DestinationRank: "8"})
+	next = next.Play(&Move{Figure: WhiteFigure(Queen), FigureFile: "b", FigureRank: "8", DestinationFile: "a",
+		DestinationRank: "8", IsCapture: true})
+	utils.AssertTestCondition(t, "R", next.Pocket, "Captured rook should go to the pocket")
+
+	next = next.Play(&Move{Figure: BlackFigure(King), FigureFile: "e", FigureRank: "8", DestinationFile: "d",
+		DestinationRank: "7"})
+	next = next.Play(&Move{Figure: WhiteFigure(King), FigureFile: "e", FigureRank: "1", DestinationFile: "e",
+		DestinationRank: "2"})
+	next = next.Play(&Move{Figure: BlackFigure(King), FigureFile: "d", FigureRank: "7", DestinationFile: "c",
+		DestinationRank: "8"})
+	next = next.Play(&Move{Figure: WhiteFigure(King), FigureFile: "e", FigureRank: "2", DestinationFile: "e",
+		DestinationRank: "3"})
+	next = next.Play(&Move{Figure: BlackFigure(King), FigureFile: "c", FigureRank: "8", DestinationFile: "b",
+		DestinationRank: "8"})
+	next = next.Play(&Move{Figure: WhiteFigure(King), FigureFile: "e", FigureRank: "3", DestinationFile: "e",
+		DestinationRank: "4"})
+	next = next.Play(&Move{Figure: BlackFigure(King), FigureFile: "b", FigureRank: "8", DestinationFile: "a",
+		DestinationRank: "8", IsCapture: true})
+	utils.AssertTestCondition(t, "Rp", next.Pocket, "Captured promoted queen should go to the pocket as pawn")
+}
+
+func TestDropMoveUCI(t *testing.T) {
+	uci, err := MoveToUCI("n@f3", false, StandardCastlingFiles)
+	utils.AssertTestCondition(t, nil, err, "Drop should be converted to UCI")
+	utils.AssertTestCondition(t, "N@f3", uci, "Drop should be written with uppercase figure")
+
+	g, _ := ReplayVariantGame(CrazyhouseVariant, 0, []string{})
+	move, err := g.UCIToMove("N@f3", false)
+	utils.AssertTestCondition(t, nil, err, "UCI drop should be converted")
+	utils.AssertTestCondition(t, "n@f3", move, "Drop should be converted to the figure of the player")
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// The seed of the Zobrist keys, which must never change, since the hashes of the positions are stored in the database
+const zobristSeed = uint64(0x9e3779b97f4a7c15)
+
+// The number of figures of the same type in the pocket which have their own keys, which is more than can be captured
+const maxPocketCount = 32
+
+// The random keys of the figures on each tile, the player on turn, each castling right, the file of the en passant
+// tile, the figures in the pocket by their count and the number of checks given, which are combined into the hash
+var zobristKeys = makeZobristKeys()
+
+type zobristKeyTable struct {
+	figures        [2][6][64]uint64
+	blackTurn      uint64
+	castlingRights [4]uint64
+	enPassantFiles [8]uint64
+	pocket         [2][6][maxPocketCount]uint64
+	checks         [2][ThreeCheckCount + 1]uint64
+}
+
+func makeZobristKeys() zobristKeyTable {
+	state := zobristSeed
+	// The SplitMix64 generator always returns the same keys for the seed, unlike the generators of math/rand
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+
+	keys := zobristKeyTable{}
+	for side := whiteSide; side <= blackSide; side++ {
+		for figureType := pawnType; figureType <= kingType; figureType++ {
+			for tile := 0; tile < 64; tile++ {
+				keys.figures[side][figureType][tile] = next()
+			}
+		}
+	}
+	keys.blackTurn = next()
+	for i := range keys.castlingRights {
+		keys.castlingRights[i] = next()
+	}
+	for file := range keys.enPassantFiles {
+		keys.enPassantFiles[file] = next()
+	}
+	for side := whiteSide; side <= blackSide; side++ {
+		for figureType := pawnType; figureType <= kingType; figureType++ {
+			for count := range keys.pocket[side][figureType] {
+				keys.pocket[side][figureType][count] = next()
+			}
+		}
+		for count := range keys.checks[side] {
+			keys.checks[side][count] = next()
+		}
+	}
+	return keys
+}
+
+// Hash returns the Zobrist hash of the current position of the game
+func (g *Game) Hash() uint64 {
+	return g.Position().Hash
+}
+
+// HashFEN returns the Zobrist hash of the position in Forsyth-Edwards Notation, which can be compared with the hashes
+// of the positions reached in the games
+func HashFEN(fen string) (uint64, error) {
+	p, err := ParseFEN(fen)
+	if err != nil {
+		return 0, err
+	}
+	return p.Hash, nil
+}
+
+// zobristHash calculates the hash of the whole position, which is then updated by each move
+func (p *Position) zobristHash() uint64 {
+	hash := uint64(0)
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			hash ^= tileHash(p.Board[i][j], i*8+j)
+		}
+	}
+	return hash ^ p.stateHash()
+}
+
+// nextHash updates the hash of the position by the move which has led to the next position, where only the tiles
+// changed by the move and the state of the game are hashed again
+func (p *Position) nextHash(next *Position, tiles uint64) uint64 {
+	hash := p.Hash ^ p.stateHash() ^ next.stateHash()
+	for ; tiles != 0; tiles &= tiles - 1 {
+		tile := bits.TrailingZeros64(tiles)
+		hash ^= tileHash(p.Board[tile/8][tile%8], tile) ^ tileHash(next.Board[tile/8][tile%8], tile)
+	}
+	return hash
+}
+
+// moveTiles returns the bitboard of the tiles changed by the move of the player on turn, which are the origin and the
+// destination tile, the tile of the pawn captured en passant and the tiles of the king and the rook in castling
+func (p *Position) moveTiles(move *Move) uint64 {
+	if move.IsKingSideCastling || move.IsQueenSideCastling {
+		homeTile := 0
+		if p.IsWhiteTurn {
+			homeTile = 56
+		}
+		files := p.castlingFiles()
+		rookFile, kingDestCol, rookDestCol := files.QueenSideRook, 2, 3
+		if move.IsKingSideCastling {
+			rookFile, kingDestCol, rookDestCol = files.KingSideRook, 6, 5
+		}
+		return uint64(1)<<(homeTile+BoardFileToColumn(files.King)) | uint64(1)<<(homeTile+BoardFileToColumn(rookFile)) |
+			uint64(1)<<(homeTile+kingDestCol) | uint64(1)<<(homeTile+rookDestCol)
+	}
+
+	destRow, destCol := BoardRankToRow(move.DestinationRank), BoardFileToColumn(move.DestinationFile)
+	tiles := uint64(1) << (destRow*8 + destCol)
+	if move.IsDrop {
+		return tiles
+	}
+
+	figureRow, figureCol := BoardRankToRow(move.FigureRank), BoardFileToColumn(move.FigureFile)
+	tiles |= uint64(1) << (figureRow*8 + figureCol)
+	if move.IsEnPassant {
+		tiles |= uint64(1) << (figureRow*8 + destCol)
+	}
+	return tiles
+}
+
+// stateHash hashes the rest of the position considered by the repetition rules besides the figures on the board
+func (p *Position) stateHash() uint64 {
+	hash := uint64(0)
+	if !p.IsWhiteTurn {
+		hash ^= zobristKeys.blackTurn
+	}
+	for _, right := range strings.TrimPrefix(p.CastlingRights, NoCastlingRights) {
+		hash ^= zobristKeys.castlingRights[strings.IndexRune(AllCastlingRights, right)]
+	}
+	if p.EnPassantTile != NoEnPassantTile {
+		hash ^= zobristKeys.enPassantFiles[BoardFileToColumn(p.EnPassantTile[:1])]
+	}
+
+	// The pocket is validated when the position is created
+	pocket, _ := parsePocket(p.Pocket)
+	for side := whiteSide; side <= blackSide; side++ {
+		for figureType, count := range pocket[side] {
+			for i := 0; i < min(count, maxPocketCount); i++ {
+				hash ^= zobristKeys.pocket[side][figureType][i]
+			}
+		}
+		if checks := min(p.Checks[side], ThreeCheckCount); checks > 0 {
+			hash ^= zobristKeys.checks[side][checks]
+		}
+	}
+	return hash
+}
+
+func tileHash(figure string, tile int) uint64 {
+	side, figureType := figureSideAndType(figure)
+	if figureType == noFigureType {
+		return 0
+	}
+	return zobristKeys.figures[side][figureType][tile]
+}
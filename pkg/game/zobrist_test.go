@@ -0,0 +1,54 @@
+package game
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+)
+
+func TestStartingPositionHash(t *testing.T) {
+	g, _ := ReplayGame(MakeStartingBoard(), []string{})
+	utils.AssertTestCondition(t, uint64(12080771400400333741), g.Hash(),
+		"Hash of the starting position should never change")
+}
+
+func TestIncrementalHash(t *testing.T) {
+	g, _, _ := playVariantMoves(t, StandardVariant, "e4 a6 e5 d5 exd6 a5 dxc7 a4 cxb8=Q a3 Nf3 axb2 Be2 bxa1=Q O-O")
+	for _, p := range g.Positions {
+		utils.AssertTestCondition(t, p.zobristHash(), p.Hash, "Updated hash should equal the hash of the position")
+	}
+
+	g, err := ReplayChess960Game(200, []string{"Nc1b3", "pa7a6", "Pd2d3", "pa6a5", "Be1d2", "pa5a4", "0-0-0"})
+	utils.AssertTestCondition(t, nil, err, "Chess960 castling should be played without error")
+	utils.AssertTestCondition(t, g.Position().zobristHash(), g.Hash(), "Updated hash should include Chess960 castling")
+}
+
+func TestIncrementalVariantHash(t *testing.T) {
+	g, _, _ := playVariantMoves(t, CrazyhouseVariant, "e4 d5 exd5 Qxd5 Nc3 Qa5 @d5")
+	for _, p := range g.Positions {
+		utils.AssertTestCondition(t, p.zobristHash(), p.Hash, "Updated hash should include the pocket")
+	}
+
+	g, _, _ = playVariantMoves(t, ThreeCheckVariant, "e4 e5 Qh5 Nc6 Qxf7+")
+	utils.AssertTestCondition(t, g.Position().zobristHash(), g.Hash(), "Updated hash should include the checks")
+
+	g, _, _ = playVariantMoves(t, AtomicVariant, "Nc3 e5 Nd5 Na6 Nxc7+")
+	utils.AssertTestCondition(t, g.Position().zobristHash(), g.Hash(), "Updated hash should include the explosion")
+}
+
+func TestTranspositionHash(t *testing.T) {
+	g1, _ := ReplayGame(MakeStartingBoard(), []string{"Ng1f3", "ng8f6", "Pd2d4"})
+	g2, _ := ReplayGame(MakeStartingBoard(), []string{"Pd2d4", "ng8f6", "Ng1f3"})
+	utils.AssertTestCondition(t, g1.Hash(), g2.Hash(), "Same position reached by other moves should have same hash")
+
+	hash, err := HashFEN(g1.FEN())
+	utils.AssertTestCondition(t, nil, err, "FEN should be valid")
+	utils.AssertTestCondition(t, g1.Hash(), hash, "Hash of the FEN should equal the hash of the game position")
+}
+
+func TestHashDiffersBySideAndRights(t *testing.T) {
+	white, _ := HashFEN("4k3/8/8/8/8/8/8/R3K2R w KQ - 0 1")
+	black, _ := HashFEN("4k3/8/8/8/8/8/8/R3K2R b KQ - 0 1")
+	noRights, _ := HashFEN("4k3/8/8/8/8/8/8/R3K2R w - - 0 1")
+	utils.AssertTestCondition(t, true, white != black, "Hash should depend on the player on turn")
+	utils.AssertTestCondition(t, true, white != noRights, "Hash should depend on the castling rights")
+}
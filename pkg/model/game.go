@@ -10,9 +10,22 @@ type Game struct {
 	BlackPlayerId       int64  `json:"blackPlayerId"`
 	BlackPlayerUsername string `json:"blackPlayerUsername"`
 	WinnerId            int64  `json:"winnerId"`
+	Result              string `json:"result"`
 	CreatorId           int64  `json:"creatorId"`
 	InProgress          bool   `json:"inProgress"`
 	Tiles               string `json:"tiles"`
+	Fen                 string `json:"fen"`
+	Variant             string `json:"variant"`
+	StartingPositionId  int32  `json:"startingPositionId"`
+	StartingFen         string `json:"startingFen"`
+	HintsEnabled        bool   `json:"hintsEnabled"`
+	Eco                 string `json:"eco"`
+	Opening             string `json:"opening"`
+	TakebacksEnabled    bool   `json:"takebacksEnabled"`
+	TakebackPlayerId    int64  `json:"takebackPlayerId"`
+	Pocket              string `json:"pocket"`
+	WhiteChecks         int32  `json:"whiteChecks"`
+	BlackChecks         int32  `json:"blackChecks"`
 	LastMovePlayedAt    string `json:"lastMovePlayedAt"`
 	StartedAt           string `json:"startedAt"`
 	EndedAt             string `json:"endedAt"`
@@ -22,3 +35,5 @@ type Game struct {
 type GameListResponse ListResponse[Game]
 
 type GameMoveListResponse ListResponse[GameMove]
+
+type GameLegalMoveListResponse ListResponse[GameLegalMove]
@@ -0,0 +1,21 @@
+package model
+
+type GameAnalysis struct {
+	GameId        int64              `json:"gameId"`
+	WhiteAccuracy float64            `json:"whiteAccuracy"`
+	BlackAccuracy float64            `json:"blackAccuracy"`
+	Moves         []GameMoveAnalysis `json:"moves"`
+	CreatedAt     string             `json:"createdAt"`
+}
+
+type GameMoveAnalysis struct {
+	GameMoveId     int64   `json:"gameMoveId"`
+	Move           string  `json:"move"`
+	San            string  `json:"san"`
+	Score          int32   `json:"score"`
+	MateIn         int     `json:"mateIn"`
+	BestMove       string  `json:"bestMove"`
+	Loss           int32   `json:"loss"`
+	Classification string  `json:"classification"`
+	Accuracy       float32 `json:"accuracy"`
+}
@@ -5,4 +5,11 @@ type GameCreate struct {
 	Password            string `json:"password"`
 	TurnDurationSeconds int32  `json:"turnDurationSeconds"`
 	IsWhite             bool   `json:"isWhite"`
+	Variant             string `json:"variant"`
+	VsComputer          bool   `json:"vsComputer"`
+	ComputerLevel       string `json:"computerLevel"`
+	DisableTakebacks    bool   `json:"disableTakebacks"`
+	Fen                 string `json:"fen"`
+	Tiles               string `json:"tiles"`
+	BlackOnTurn         bool   `json:"blackOnTurn"`
 }
@@ -0,0 +1,5 @@
+package model
+
+type GameHints struct {
+	Enabled bool `json:"enabled"`
+}
@@ -0,0 +1,5 @@
+package model
+
+type GameImport struct {
+	Pgn string `json:"pgn"`
+}
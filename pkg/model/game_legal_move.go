@@ -0,0 +1,7 @@
+package model
+
+type GameLegalMove struct {
+	Move string `json:"move"`
+	San  string `json:"san"`
+	Uci  string `json:"uci"`
+}
@@ -1,5 +1,6 @@
 package model
 
 type GameMakeMove struct {
-	Move string `json:"move"`
+	Move   string `json:"move"`
+	Format string `json:"format"`
 }
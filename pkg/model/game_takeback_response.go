@@ -0,0 +1,5 @@
+package model
+
+type GameTakebackResponse struct {
+	Accept bool `json:"accept"`
+}
@@ -8,7 +8,9 @@ type Player struct {
 	Draws        int32   `json:"draws"`
 	Rate         float32 `json:"rate"`
 	Elo          int32   `json:"elo"`
+	PuzzleElo    int32   `json:"puzzleElo"`
 	IsPlaying    bool    `json:"isPlaying"`
+	IsBot        bool    `json:"isBot"`
 	LastPlayedAt string  `json:"lastPlayedAt"`
 	CreatedAt    string  `json:"createdAt"`
 }
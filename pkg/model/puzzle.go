@@ -0,0 +1,39 @@
+package model
+
+type Puzzle struct {
+	Id          int64    `json:"id"`
+	LichessId   string   `json:"lichessId"`
+	Fen         string   `json:"fen"`
+	Tiles       string   `json:"tiles"`
+	IsWhiteTurn bool     `json:"isWhiteTurn"`
+	Rating      int32    `json:"rating"`
+	Themes      []string `json:"themes"`
+	Plays       int32    `json:"plays"`
+	CreatedAt   string   `json:"createdAt"`
+}
+
+type PuzzleAttempt struct {
+	Moves []string `json:"moves"`
+}
+
+type PuzzleAttemptResult struct {
+	Correct      bool     `json:"correct"`
+	Solved       bool     `json:"solved"`
+	Reply        string   `json:"reply"`
+	ReplySan     string   `json:"replySan"`
+	Fen          string   `json:"fen"`
+	Tiles        string   `json:"tiles"`
+	Solution     []string `json:"solution"`
+	PuzzleRating int32    `json:"puzzleRating"`
+	PlayerRating int32    `json:"playerRating"`
+	RatingChange int32    `json:"ratingChange"`
+}
+
+type PuzzleImport struct {
+	Csv string `json:"csv"`
+}
+
+type PuzzleImportResult struct {
+	Parsed   int `json:"parsed"`
+	Imported int `json:"imported"`
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/lmatosevic/chess-cli/configs"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"net/http"
+)
+
+// GetGameAnalysis godoc
+// @Summary Get game analysis
+// @Description Get the analysis of the ended game with the score after each move in centipawns from the white
+// @Description player's point of view, the best move, the classification of inaccuracies, mistakes and blunders and
+// @Description the accuracy of each player
+// @Tags games
+// @Produce json
+// @Param id path int true "Game ID"
+// @Success 200 {object} model.GameAnalysis "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/analysis [get]
+func GetGameAnalysis(c *gin.Context) {
+	_, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if g.InProgress || !g.EndedAt.Valid {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: "Analysis is available only for ended games"})
+		return
+	}
+
+	analysis, err := repository.FindGameAnalysis(g.Id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+	if analysis.Error.Valid {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false,
+			Error: fmt.Sprintf("Game analysis has failed: %s", analysis.Error.String)})
+		return
+	}
+
+	moveAnalyses, err := repository.QueryGameMoveAnalyses(g.Id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	gameMoves, err := queryAnalyzedGameMoves(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	moves := make([]string, 0)
+	for _, m := range gameMoves {
+		moves = append(moves, m.Move)
+	}
+
+	startingGame, err := ReplayGameMoves(g, []string{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	sanMoves, err := startingGame.Position().SANMoves(moves)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	moveIndexes := make(map[int64]int)
+	for i, m := range gameMoves {
+		moveIndexes[m.Id] = i
+	}
+
+	movesDTO := make([]model.GameMoveAnalysis, 0)
+	for _, a := range *moveAnalyses {
+		i, ok := moveIndexes[a.GameMoveId]
+		if !ok {
+			continue
+		}
+		movesDTO = append(movesDTO, model.GameMoveAnalysis{GameMoveId: a.GameMoveId, Move: moves[i], San: sanMoves[i],
+			Score: a.Score, MateIn: ai.MateIn(int(a.Score)), BestMove: a.BestMove, Loss: a.Loss,
+			Classification: a.Classification, Accuracy: a.Accuracy})
+	}
+
+	c.JSON(http.StatusOK, model.GameAnalysis{GameId: g.Id, WhiteAccuracy: analysis.WhiteAccuracy.Float64,
+		BlackAccuracy: analysis.BlackAccuracy.Float64, Moves: movesDTO, CreatedAt: analysis.FormatCreatedAt()})
+}
+
+// AnalyzeEndedGame godoc
+// Searches every position of the ended game with the search limited by the configured level and stores the analysis
+// of each move played in it. The game which could not be analyzed is stored with the error, so it is not analyzed
+// again.
+func AnalyzeEndedGame(g *repository.Game) error {
+	conf := configs.GetConfig()
+
+	moveAnalyses, analysis, err := analyzeGameMoves(g, conf.Analysis.Level)
+	if err != nil {
+		return repository.CreateGameAnalysis(&repository.GameAnalysis{GameId: g.Id,
+			Error: sql.NullString{String: err.Error(), Valid: true}}, []repository.GameMoveAnalysis{})
+	}
+
+	return repository.CreateGameAnalysis(analysis, moveAnalyses)
+}
+
+func analyzeGameMoves(g *repository.Game, level string) ([]repository.GameMoveAnalysis, *repository.GameAnalysis,
+	error) {
+	gameMoves, err := queryAnalyzedGameMoves(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moves := make([]string, 0)
+	for _, m := range gameMoves {
+		moves = append(moves, m.Move)
+	}
+
+	gameModel, err := ReplayGameMoves(g, moves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := ai.AnalyzeGame(gameModel, level)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result.Moves) != len(gameMoves) {
+		return nil, nil, errors.New(fmt.Sprintf("analyzed %d moves of %d played", len(result.Moves),
+			len(gameMoves)))
+	}
+
+	moveAnalyses := make([]repository.GameMoveAnalysis, 0)
+	for i, m := range result.Moves {
+		bestMove := ""
+		if m.BestMove != "" {
+			position := gameModel.Positions[i]
+			sanMoves, e := position.SANMoves([]string{m.BestMove})
+			if e != nil {
+				return nil, nil, e
+			}
+			bestMove = sanMoves[0]
+		}
+
+		moveAnalyses = append(moveAnalyses, repository.GameMoveAnalysis{GameMoveId: gameMoves[i].Id,
+			Score: int32(m.Score), BestMove: bestMove, Loss: int32(m.Loss), Classification: m.Classification,
+			Accuracy: float32(m.Accuracy)})
+	}
+
+	return moveAnalyses, &repository.GameAnalysis{GameId: g.Id,
+		WhiteAccuracy: sql.NullFloat64{Float64: result.WhiteAccuracy, Valid: true},
+		BlackAccuracy: sql.NullFloat64{Float64: result.BlackAccuracy, Valid: true}}, nil
+}
+
+// queryAnalyzedGameMoves returns the moves played in the game in order without the draw offers and claims, which are
+// not analyzed
+func queryAnalyzedGameMoves(g *repository.Game) ([]repository.GameMove, error) {
+	gameMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, g.Id), 1, 10000, "createdAt")
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make([]repository.GameMove, 0)
+	for _, m := range *gameMoves {
+		if game.IsDrawMove(m.Move) {
+			continue
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
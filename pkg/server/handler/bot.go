@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lmatosevic/chess-cli/configs"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/engine"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BotUsernamePrefix is followed by the level in the username of the computer player, e.g. computer-medium
+const BotUsernamePrefix = "computer-"
+
+// EngineBotLevel is the level of the computer player which plays the moves of the UCI engine configured on the server
+const EngineBotLevel = "engine"
+
+// The computer player accepts the draw offer only when its position is worse than this score in centipawns
+const botDrawAcceptScore = -200
+
+// The IDs of the games in which the computer player is currently searching for the move
+var botGames sync.Map
+
+// validateBotLevel checks whether the computer player of the level can play on the server
+func validateBotLevel(level string) error {
+	if level == EngineBotLevel {
+		if configs.GetConfig().Engine.Path == "" {
+			return errors.New("Engine computer player is not configured on the server")
+		}
+		return nil
+	}
+
+	if _, err := ai.FindLevel(level); err != nil {
+		return errors.New(fmt.Sprintf("Unsupported computer level: %s", level))
+	}
+	return nil
+}
+
+// joinBotPlayer makes the computer player of the level join the game as the opponent of the player who created it
+func joinBotPlayer(g *repository.Game, level string) error {
+	bot, err := repository.FindPlayerByUsername(BotUsernamePrefix + level)
+	if err != nil || !bot.IsBot {
+		return errors.New(fmt.Sprintf("Computer player of level %s is not available", level))
+	}
+
+	g.StartedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	g.InProgress = true
+
+	side := "white"
+	botId := sql.NullInt64{Int64: bot.Id, Valid: true}
+	botUsername := sql.NullString{String: bot.Username, Valid: true}
+	if g.WhitePlayerId.Valid {
+		g.BlackPlayerId, g.BlackPlayerUsername = botId, botUsername
+		side = "black"
+	} else {
+		g.WhitePlayerId, g.WhitePlayerUsername = botId, botUsername
+	}
+
+	err = repository.UpdateGame(g)
+	if err != nil {
+		return err
+	}
+
+	bot.RefreshIsPlaying()
+	err = repository.UpdatePlayer(bot)
+	if err != nil {
+		return err
+	}
+
+	SendEvent(GameJoinEvent, g.Id, bot.Id, side)
+
+	return nil
+}
+
+// PlayBotMove godoc
+// Makes the move of the computer player if it is on turn in the game. The move is searched in the background, so the
+// request of the opponent is not delayed, and only one search is running in the game at the time.
+func PlayBotMove(gameId int64) {
+	if _, isSearching := botGames.LoadOrStore(gameId, true); isSearching {
+		return
+	}
+
+	go func() {
+		defer botGames.Delete(gameId)
+
+		err := playBotMove(gameId)
+		if err != nil {
+			log.Printf("Error while playing computer move in game with ID %d: %s", gameId, err.Error())
+		}
+	}()
+}
+
+func playBotMove(gameId int64) error {
+	g, err := repository.FindGameById(gameId)
+	if err != nil {
+		return err
+	}
+	if !g.InProgress {
+		return nil
+	}
+
+	gameMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, g.Id), 1, 10000, "createdAt")
+	if err != nil {
+		return err
+	}
+
+	botId := g.WhitePlayerId.Int64
+	if !game.IsWhiteFirstOnTurn(g.StartingFen.String) {
+		botId = g.BlackPlayerId.Int64
+	}
+	var lastMove *repository.GameMove
+	if len(*gameMoves) > 0 {
+		lastMove = &(*gameMoves)[len(*gameMoves)-1]
+		botId = g.WhitePlayerId.Int64
+		if lastMove.PlayerId.Int64 == g.WhitePlayerId.Int64 {
+			botId = g.BlackPlayerId.Int64
+		}
+	}
+
+	bot, err := repository.FindPlayerById(botId)
+	if err != nil {
+		return err
+	}
+	if !bot.IsBot {
+		return nil
+	}
+
+	moves := make([]string, 0)
+	for _, m := range *gameMoves {
+		moves = append(moves, m.Move)
+	}
+
+	gameModel, err := ReplayGameMoves(g, moves)
+	if err != nil {
+		return err
+	}
+
+	if lastMove != nil && lastMove.Move == game.DrawOfferMove {
+		move := game.DrawOfferRejectMove
+		if ai.Evaluate(gameModel.Position()) < botDrawAcceptScore {
+			move = game.DrawOfferMove
+		}
+		return recordGameMove(g, bot, gameModel, move, game.NoOutcome, move == game.DrawOfferMove)
+	}
+
+	level := strings.TrimPrefix(bot.Username, BotUsernamePrefix)
+	move := ""
+	if level == EngineBotLevel {
+		move, err = engineBestMove(gameModel, g.Variant == game.Chess960Variant)
+	} else {
+		move, err = ai.BestMove(gameModel, level)
+	}
+	if err != nil {
+		return err
+	}
+
+	g, err = reloadBotGame(gameId, gameMoves)
+	if err != nil || g == nil {
+		return err
+	}
+
+	move, outcome, err := gameModel.MakeMove(move, g.WhitePlayerId.Int64 == bot.Id)
+	if err != nil {
+		return err
+	}
+
+	return recordGameMove(g, bot, gameModel, move, outcome, false)
+}
+
+// reloadBotGame loads the game again after the search of the computer player, which can take several seconds. The game
+// could have ended or its moves could have been taken back in the meantime, so nil is returned if the game is no longer
+// in progress or its moves are not the ones which were searched.
+func reloadBotGame(gameId int64, gameMoves *[]repository.GameMove) (*repository.Game, error) {
+	g, err := repository.FindGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+	if !g.InProgress {
+		return nil, nil
+	}
+
+	currentMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, g.Id), 1, 10000, "createdAt")
+	if err != nil {
+		return nil, err
+	}
+	if len(*currentMoves) != len(*gameMoves) ||
+		(len(*gameMoves) > 0 && (*currentMoves)[len(*currentMoves)-1].Id != (*gameMoves)[len(*gameMoves)-1].Id) {
+		return nil, nil
+	}
+
+	return g, nil
+}
+
+// engineBestMove searches the current position of the game with the UCI engine configured on the server
+func engineBestMove(gameModel *game.Game, isChess960 bool) (string, error) {
+	conf := *configs.GetConfig()
+	_, result, err := engine.AnalyzePosition(conf.Engine.Path, gameModel.FEN(), isChess960,
+		time.Duration(conf.Engine.MoveTimeMillis)*time.Millisecond)
+	if err != nil {
+		return "", err
+	}
+
+	return gameModel.UCIToMove(result.BestMove, gameModel.Position().IsWhiteTurn)
+}
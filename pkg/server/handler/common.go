@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/lmatosevic/chess-cli/configs"
 	"github.com/lmatosevic/chess-cli/pkg/database/repository"
 	"strconv"
 	"strings"
@@ -60,3 +61,13 @@ func GetAuthPlayer(c *gin.Context) (*repository.Player, error) {
 
 	return p, nil
 }
+
+func IsAdminPlayer(player *repository.Player) bool {
+	conf := *configs.GetConfig()
+	for _, username := range strings.Split(conf.Server.Admins, ",") {
+		if strings.TrimSpace(username) == player.Username {
+			return true
+		}
+	}
+	return false
+}
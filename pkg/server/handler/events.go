@@ -23,9 +23,23 @@ const (
 	GameEndEvent             = "GameEndEvent"
 	GameWhitePlayerMoveEvent = "GameWhitePlayerMoveEvent"
 	GameBlackPlayerMoveEvent = "GameBlackPlayerMoveEvent"
+	GameTakebackEvent        = "GameTakebackEvent"
 	PlayerMessage            = "PlayerMessage"
 )
 
+// The reasons of the game end besides the outcomes of the game decided by the moves (e.g. checkmate, stalemate)
+const (
+	GameEndAgreement = "agreement"
+	GameEndSurrender = "surrender"
+	GameEndTimeout   = "timeout"
+)
+
+const (
+	TakebackRequest = "request"
+	TakebackAccept  = "accept"
+	TakebackDecline = "decline"
+)
+
 var eventChannels = make(map[string]chan model.Event)
 
 func SendEvent(eventType string, gameId int64, playerId int64, payload string) {
@@ -44,7 +58,7 @@ func SendEvent(eventType string, gameId int64, playerId int64, payload string) {
 // @Accept json
 // @Produce text/event-stream
 // @Param token query string true "Access token"
-// @Param event query string true "Event type" Enums(GameAnyEvent, GameMoveEvent, GameJoinEvent, GameQuitEvent, GameStartEvent, GameEndEvent, GameWhitePlayerMoveEvent, GameWhitePlayerMoveEvent)
+// @Param event query string true "Event type" Enums(GameAnyEvent, GameMoveEvent, GameJoinEvent, GameQuitEvent, GameStartEvent, GameEndEvent, GameWhitePlayerMoveEvent, GameWhitePlayerMoveEvent, GameTakebackEvent)
 // @Param gameId query int false "Game ID"
 // @Success 200 {object} model.Event "Ok"
 // @Failure 400 {object} model.ErrorResponse "Bad Request"
@@ -125,7 +139,7 @@ func SubscribeToEvent(c *gin.Context) {
 
 func IsValidEventType(eventType string) bool {
 	return slices.Contains([]string{GameAnyEvent, GameMoveEvent, GameJoinEvent, GameQuitEvent, GameStartEvent,
-		GameEndEvent, GameWhitePlayerMoveEvent, GameBlackPlayerMoveEvent, PlayerMessage}, eventType)
+		GameEndEvent, GameWhitePlayerMoveEvent, GameBlackPlayerMoveEvent, GameTakebackEvent, PlayerMessage}, eventType)
 }
 
 func shouldReceiveEvent(event model.Event, eventType string, game *repository.Game, player *repository.Player) bool {
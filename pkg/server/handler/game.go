@@ -2,14 +2,18 @@ package handler
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/lmatosevic/chess-cli/configs"
+	"github.com/lmatosevic/chess-cli/pkg/ai"
 	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/eco"
 	"github.com/lmatosevic/chess-cli/pkg/game"
 	"github.com/lmatosevic/chess-cli/pkg/model"
 	"github.com/lmatosevic/chess-cli/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
+	"log"
 	"math"
 	"net/http"
 	"strconv"
@@ -95,12 +99,23 @@ func FindOneGame(c *gin.Context) {
 		return
 	}
 
+	// The games started before positions were stored in FEN have it recreated from their moves
+	if !g.Fen.Valid {
+		gameModel, e := replayGameModel(g)
+		if e != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
+			return
+		}
+		g.Fen = sql.NullString{String: gameModel.FEN(), Valid: true}
+	}
+
 	c.JSON(http.StatusOK, makeGameDTO(g))
 }
 
 // CreateGame godoc
 // @Summary Create new game
-// @Description Create new game
+// @Description Create new game, which the computer player of the chosen level joins immediately in vs computer mode.
+// @Description The game can start from the custom position set either by FEN or by tiles and the player on turn.
 // @Tags games
 // @Accept json
 // @Produce json
@@ -125,6 +140,30 @@ func CreateGame(c *gin.Context) {
 		return
 	}
 
+	variant := gc.Variant
+	if variant == "" {
+		variant = game.StandardVariant
+	}
+	if !game.IsValidVariant(variant) {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: fmt.Sprintf("Unsupported game variant: %s", variant)})
+		return
+	}
+
+	computerLevel := gc.ComputerLevel
+	if computerLevel == "" {
+		computerLevel = ai.MediumLevel
+	}
+	if e := validateBotLevel(computerLevel); e != nil && gc.VsComputer {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: e.Error()})
+		return
+	}
+	if gc.VsComputer && computerLevel == EngineBotLevel && game.FindVariant(variant) != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: "Engine computer player supports only standard chess and Chess960"})
+		return
+	}
+
 	maxCreatedGames := int(conf.Rules.MaxCreatedGames)
 	createdGames, err := repository.QueryGames(fmt.Sprintf("creatorId=%d;and;endedAt=null", player.Id), 1,
 		maxCreatedGames, "")
@@ -142,13 +181,65 @@ func CreateGame(c *gin.Context) {
 		turnDuration = conf.Rules.DefaultTurnDurationSeconds
 	}
 
-	g, err := repository.CreateGame(gc.Name, gc.Password, turnDuration, player, gc.IsWhite,
-		game.MakeStartingBoard())
+	startingFen, err := parseCustomStartingPosition(&gc, variant)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	startingPositionId := game.StandardStartingPositionId
+	if variant == game.Chess960Variant && !startingFen.Valid {
+		startingPositionId = game.RandomChess960PositionId()
+	}
+	g := &repository.Game{Variant: variant, StartingPositionId: int32(startingPositionId), StartingFen: startingFen}
+	startingGame, err := ReplayGameMoves(g, []string{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	g, err = repository.CreateGame(gc.Name, gc.Password, turnDuration, player, gc.IsWhite, variant,
+		int32(startingPositionId), startingFen, startingGame.GetTiles(), startingGame.FEN(), !gc.DisableTakebacks)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	err = repository.CreateGamePosition(g.Id, 0, startingGame.Hash())
 	if err != nil {
+		_ = repository.DeleteGame(g.Id)
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
 		return
 	}
 
+	// The custom starting position of Crazyhouse can have the figures in the pocket already
+	if startingGame.Position().Pocket != "" {
+		setGamePosition(g, startingGame)
+		err = repository.UpdateGame(g)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+			return
+		}
+	}
+
+	if gc.VsComputer {
+		err = joinBotPlayer(g, computerLevel)
+		if err != nil {
+			_ = repository.DeleteGame(g.Id)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+			return
+		}
+
+		player.RefreshIsPlaying()
+		err = repository.UpdatePlayer(player)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+			return
+		}
+
+		PlayBotMove(g.Id)
+	}
+
 	c.JSON(http.StatusOK, makeGameDTO(g))
 }
 
@@ -322,7 +413,7 @@ func QuitGame(c *gin.Context) {
 		return
 	}
 
-	err = UpdateEndGameState(g, winnerPlayer, player, false)
+	err = UpdateEndGameState(g, winnerPlayer, player, false, GameEndSurrender)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
 		return
@@ -380,9 +471,13 @@ func MakeGameMove(c *gin.Context) {
 			c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Its the other players turn"})
 			return
 		}
-	} else if player.Id != g.WhitePlayerId.Int64 {
+	} else if isWhiteFirst := game.IsWhiteFirstOnTurn(g.StartingFen.String); isWhiteFirst &&
+		player.Id != g.WhitePlayerId.Int64 {
 		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "The white player is first on turn"})
 		return
+	} else if !isWhiteFirst && player.Id != g.BlackPlayerId.Int64 {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "The black player is first on turn"})
+		return
 	}
 
 	var moves []string
@@ -390,7 +485,7 @@ func MakeGameMove(c *gin.Context) {
 		moves = append(moves, m.Move)
 	}
 
-	gameModel, err := game.MakeGame(g.Tiles, moves)
+	gameModel, err := ReplayGameMoves(g, moves)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
 		return
@@ -402,6 +497,24 @@ func MakeGameMove(c *gin.Context) {
 		return
 	}
 
+	isWhite := g.WhitePlayerId.Int64 == player.Id
+	inputMove := gm.Move
+	switch gm.Format {
+	case "", game.SANMoveFormat:
+	case game.UCIMoveFormat:
+		if !game.IsDrawMove(gm.Move) {
+			inputMove, err = gameModel.UCIToMove(gm.Move, isWhite)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+				return
+			}
+		}
+	default:
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: fmt.Sprintf("Unsupported move format: %s", gm.Format)})
+		return
+	}
+
 	isDraw := false
 	if lastMove != nil && lastMove.Move == game.DrawOfferMove {
 		if gm.Move == game.DrawOfferMove {
@@ -434,57 +547,26 @@ func MakeGameMove(c *gin.Context) {
 		return
 	}
 
-	move, isWin, err := gameModel.MakeMove(gm.Move, g.WhitePlayerId.Int64 == player.Id)
+	move, outcome, err := gameModel.MakeMove(inputMove, isWhite)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
 		return
 	}
 
-	err = repository.CreateGameMove(g.Id, player.Id, move)
+	err = recordGameMove(g, player, gameModel, move, outcome, isDraw)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
 		return
 	}
 
-	g.Tiles = gameModel.GetTiles()
-	g.LastMovePlayedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
-	player.LastPlayedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
-	if isWin || isDraw {
-		otherPlayerId := g.WhitePlayerId
-		if g.WhitePlayerId.Int64 == player.Id {
-			otherPlayerId = g.BlackPlayerId
-		}
-
-		otherPlayer, e := repository.FindPlayerById(otherPlayerId.Int64)
-		if e != nil {
-			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
-			return
-		}
-
-		e = UpdateEndGameState(g, player, otherPlayer, isDraw)
-		if e != nil {
-			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
-			return
-		}
-	} else {
-		err = repository.UpdatePlayer(player)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
-			return
-		}
-		err = repository.UpdateGame(g)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
-			return
-		}
+	if g.InProgress {
+		PlayBotMove(g.Id)
 	}
 
-	SendEvent(GameMoveEvent, g.Id, player.Id, move)
-
-	if g.WhitePlayerId.Int64 == player.Id {
-		SendEvent(GameWhitePlayerMoveEvent, g.Id, player.Id, move)
-	} else {
-		SendEvent(GameBlackPlayerMoveEvent, g.Id, player.Id, move)
+	if gm.Format == game.UCIMoveFormat {
+		move, _ = game.MoveToUCI(move, isWhite, gameModel.Position().CastlingFiles)
+	} else if gm.Format == game.SANMoveFormat && !game.IsDrawMove(move) {
+		move = gameModel.LastMoveSAN()
 	}
 
 	c.JSON(http.StatusOK, model.GenericResponse{Success: true, Data: move})
@@ -499,7 +581,9 @@ func MakeGameMove(c *gin.Context) {
 // @Param size query int false "Size"
 // @Param sort query string false "Sort"
 // @Param filter query string false "Filter"
-// @Success 200 {object} model.GameListResponse "Ok"
+// @Param format query string false "Move format (san, uci)"
+// @Success 200 {object} model.GameMoveListResponse "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized"
 // @Failure 500 {object} model.ErrorResponse "Internal Server Error"
 // @Security ApiKeyAuth
@@ -525,9 +609,30 @@ func ListGameMoves(c *gin.Context) {
 		return
 	}
 
+	format := c.Query("format")
+	if format != "" && format != game.SANMoveFormat && format != game.UCIMoveFormat {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: fmt.Sprintf("Unsupported move format: %s", format)})
+		return
+	}
+
+	// The side of each move and the castling files of the game are needed for UCI castling moves, and the position
+	// before each move for SAN moves, so all moves of the game are converted from its starting position
+	formattedMoves := make(map[int64]string)
 	gameMovesDTO := make([]model.GameMove, 0)
 	for _, gm := range *gameMoves {
-		gameMovesDTO = append(gameMovesDTO, makeGameMoveDTO(&gm))
+		dto := makeGameMoveDTO(&gm)
+		if format != "" {
+			if _, ok := formattedMoves[gm.Id]; !ok {
+				err = addGameFormattedMoves(gm.GameId, format, formattedMoves)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+					return
+				}
+			}
+			dto.Move = formattedMoves[gm.Id]
+		}
+		gameMovesDTO = append(gameMovesDTO, dto)
 	}
 
 	c.JSON(http.StatusOK, model.ListResponse[model.GameMove]{
@@ -537,14 +642,245 @@ func ListGameMoves(c *gin.Context) {
 	})
 }
 
-func UpdateEndGameState(game *repository.Game, winner *repository.Player, loser *repository.Player, isDraw bool) error {
-	winnerElo := winner.Elo
-	loserElo := loser.Elo
+// ListGameLegalMoves godoc
+// @Summary List legal moves
+// @Description List all legal moves of the player on turn in the current position of the game in progress
+// @Tags games
+// @Produce json
+// @Param id path int true "Game ID"
+// @Success 200 {object} model.GameLegalMoveListResponse "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/legal-moves [get]
+func ListGameLegalMoves(c *gin.Context) {
+	_, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	legalMovesDTO := make([]model.GameLegalMove, 0)
+	if !g.InProgress {
+		c.JSON(http.StatusOK, model.ListResponse[model.GameLegalMove]{Items: legalMovesDTO})
+		return
+	}
+
+	gameModel, err := replayGameModel(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	position := gameModel.Position()
+	for _, m := range gameModel.LegalMoves() {
+		move := m.String()
+		uci, _ := game.MoveToUCI(move, position.IsWhiteTurn, position.CastlingFiles)
+		legalMovesDTO = append(legalMovesDTO, model.GameLegalMove{Move: move, San: position.MoveToSAN(&m), Uci: uci})
+	}
+
+	c.JSON(http.StatusOK, model.ListResponse[model.GameLegalMove]{
+		Items:       legalMovesDTO,
+		ResultCount: len(legalMovesDTO),
+		TotalCount:  len(legalMovesDTO),
+	})
+}
+
+// ExportGamePGN godoc
+// @Summary Export game in PGN
+// @Description Export game in Portable Game Notation with Seven Tag Roster and moves in Standard Algebraic Notation
+// @Tags games
+// @Produce json
+// @Param id path int true "Game ID"
+// @Success 200 {object} model.GenericResponse "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/pgn [get]
+func ExportGamePGN(c *gin.Context) {
+	conf := *configs.GetConfig()
+	_, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	moves, err := queryGameMovesList(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	date := g.CreatedAt
+	if g.StartedAt.Valid {
+		date = g.StartedAt.Time
+	}
+
+	tags := []game.PGNTag{
+		{Name: game.PGNEventTag, Value: pgnTagValue(g.Name)},
+		{Name: game.PGNSiteTag, Value: pgnTagValue(conf.Server.Hostname)},
+		{Name: game.PGNDateTag, Value: date.Format("2006.01.02")},
+		{Name: game.PGNRoundTag, Value: "-"},
+		{Name: game.PGNWhiteTag, Value: pgnTagValue(g.WhitePlayerUsername.String)},
+		{Name: game.PGNBlackTag, Value: pgnTagValue(g.BlackPlayerUsername.String)},
+		{Name: game.PGNResultTag, Value: gameResult(g)},
+	}
+	if g.Variant == game.Chess960Variant {
+		tags = append(tags, game.PGNTag{Name: game.PGNVariantTag, Value: game.PGNChess960Variant})
+	} else if v := game.FindVariant(g.Variant); v != nil {
+		tags = append(tags, game.PGNTag{Name: game.PGNVariantTag, Value: v.Title()})
+	}
+
+	startingGame, err := ReplayGameMoves(g, []string{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	pgn, err := game.MakePGN(tags, startingGame.FEN(), moves)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.GenericResponse{Success: true, Data: pgn.String()})
+}
+
+// ImportGames godoc
+// @Summary Import games from PGN
+// @Description Import finished games from PGN after replaying them through the rules engine, only for admins
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param game body model.GameImport true "Import games"
+// @Success 200 {object} model.GameListResponse "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/import [post]
+func ImportGames(c *gin.Context) {
+	player, err := GetAuthPlayer(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if !IsAdminPlayer(player) {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Only admins can import games"})
+		return
+	}
 
+	gi, err := utils.ParseJson[model.GameImport](c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	pgnGames, err := game.ParsePGN(gi.Pgn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	// All games are validated before any of them is imported
+	gameModels := make([]*game.Game, 0)
+	gamesMoves := make([][]string, 0)
+	for i, p := range pgnGames {
+		if p.Result == game.UnknownResult {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+				Error: fmt.Sprintf("Game %d is not finished, only finished games can be imported", i+1)})
+			return
+		}
+		if p.Tag(game.PGNFENTag) != "" && p.Tag(game.PGNFENTag) != game.StartingFEN {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+				Error: fmt.Sprintf("Game %d does not start from the standard starting position", i+1)})
+			return
+		}
+
+		gameModel, moves, _, e := p.Replay()
+		if e != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+				Error: fmt.Sprintf("Game %d cannot be replayed: %s", i+1, e.Error())})
+			return
+		}
+		gameModels = append(gameModels, gameModel)
+		gamesMoves = append(gamesMoves, moves)
+	}
+
+	gamesDTO := make([]model.Game, 0)
+	for i, p := range pgnGames {
+		g, e := importGame(player, &p, gameModels[i], gamesMoves[i])
+		if e != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
+			return
+		}
+		gamesDTO = append(gamesDTO, makeGameDTO(g))
+	}
+
+	c.JSON(http.StatusOK, model.ListResponse[model.Game]{
+		Items:       gamesDTO,
+		ResultCount: len(gamesDTO),
+		TotalCount:  len(gamesDTO),
+	})
+}
+
+// UpdateGameHints godoc
+// @Summary Enable or disable hints
+// @Description Enable or disable the move hints in the game (e.g. for rated games), only for admins
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param id path int true "Game ID"
+// @Param hints body model.GameHints true "Game hints"
+// @Success 200 {object} model.Game "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/hints [put]
+func UpdateGameHints(c *gin.Context) {
+	player, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if !IsAdminPlayer(player) {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Only admins can change game hints"})
+		return
+	}
+
+	gh, err := utils.ParseJson[model.GameHints](c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	g.HintsEnabled = gh.Enabled
+	err = repository.UpdateGame(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, makeGameDTO(g))
+}
+
+// UpdateEndGameState godoc
+// Ends the game and updates the statistics of both players. The reason describes how the game has ended
+// (e.g. checkmate, stalemate, timeout) and it is sent in the GameEndEvent payload along the game status.
+func UpdateEndGameState(game *repository.Game, winner *repository.Player, loser *repository.Player, isDraw bool,
+	reason string) error {
 	// Update game data
 	if !isDraw {
 		game.WinnerId = sql.NullInt64{Int64: winner.Id, Valid: true}
 	}
+	game.Result = sql.NullString{String: endGameResult(game, winner, isDraw), Valid: true}
 	game.EndedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
 	game.InProgress = false
 
@@ -553,6 +889,25 @@ func UpdateEndGameState(game *repository.Game, winner *repository.Player, loser
 		return err
 	}
 
+	err = updatePlayersStats(winner, loser, isDraw)
+	if err != nil {
+		return err
+	}
+
+	status := "win"
+	if isDraw {
+		status = "draw"
+	}
+
+	SendEvent(GameEndEvent, game.Id, winner.Id, fmt.Sprintf("%s:%s", status, reason))
+
+	return nil
+}
+
+func updatePlayersStats(winner *repository.Player, loser *repository.Player, isDraw bool) error {
+	winnerElo := winner.Elo
+	loserElo := loser.Elo
+
 	if !isDraw {
 		// Update winner player data
 		winner.Wins = winner.Wins + 1
@@ -565,7 +920,7 @@ func UpdateEndGameState(game *repository.Game, winner *repository.Player, loser
 
 	winner.RefreshIsPlaying()
 
-	err = repository.UpdatePlayer(winner)
+	err := repository.UpdatePlayer(winner)
 	if err != nil {
 		return err
 	}
@@ -582,19 +937,95 @@ func UpdateEndGameState(game *repository.Game, winner *repository.Player, loser
 
 	loser.RefreshIsPlaying()
 
-	err = repository.UpdatePlayer(loser)
+	return repository.UpdatePlayer(loser)
+}
+
+// The players of imported game are matched with registered players by username, whose statistics are then updated
+// only if both of them are registered
+func importGame(creator *repository.Player, p *game.PGN, gameModel *game.Game, moves []string) (*repository.Game,
+	error) {
+	whitePlayer, _ := repository.FindPlayerByUsername(p.Tag(game.PGNWhiteTag))
+	blackPlayer, _ := repository.FindPlayerByUsername(p.Tag(game.PGNBlackTag))
+	g := makeImportedGame(creator, p, gameModel, whitePlayer, blackPlayer)
+
+	gameMoves := make([]repository.GameMove, 0)
+	for i, m := range moves {
+		playerId := g.WhitePlayerId
+		if i%2 == 1 {
+			playerId = g.BlackPlayerId
+		}
+		gameMoves = append(gameMoves, repository.GameMove{PlayerId: playerId, Move: m})
+	}
+
+	gamePositions := make([]repository.GamePosition, 0)
+	for i, position := range gameModel.Positions {
+		gamePositions = append(gamePositions, repository.GamePosition{Ply: int32(i), Hash: int64(position.Hash)})
+	}
+
+	imported, err := repository.ImportGame(&g, gameMoves, gamePositions)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	status := "win"
-	if isDraw {
-		status = "draw"
+	if whitePlayer != nil && blackPlayer != nil && whitePlayer.Id != blackPlayer.Id {
+		winner, loser := whitePlayer, blackPlayer
+		if p.Result == game.BlackWinsResult {
+			winner, loser = blackPlayer, whitePlayer
+		}
+		err = updatePlayersStats(winner, loser, p.Result == game.DrawResult)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	SendEvent(GameEndEvent, game.Id, winner.Id, status)
+	return imported, nil
+}
 
-	return nil
+// makeImportedGame creates the ended game from the PGN with the registered players, which are nil if the players of
+// the game are not registered
+func makeImportedGame(creator *repository.Player, p *game.PGN, gameModel *game.Game, whitePlayer *repository.Player,
+	blackPlayer *repository.Player) repository.Game {
+	playedAt := time.Now().UTC()
+	if date, err := time.Parse("2006.01.02", p.Tag(game.PGNDateTag)); err == nil {
+		playedAt = date
+	}
+
+	name := p.Tag(game.PGNEventTag)
+	if name == "" || name == "?" {
+		name = "Imported game"
+	}
+
+	g := repository.Game{Name: name, Tiles: gameModel.GetTiles(), Variant: game.StandardVariant,
+		Fen:                 sql.NullString{String: gameModel.FEN(), Valid: true},
+		WhitePlayerUsername: sql.NullString{String: p.Tag(game.PGNWhiteTag), Valid: p.Tag(game.PGNWhiteTag) != ""},
+		BlackPlayerUsername: sql.NullString{String: p.Tag(game.PGNBlackTag), Valid: p.Tag(game.PGNBlackTag) != ""},
+		CreatorId:           sql.NullInt64{Int64: creator.Id, Valid: true},
+		LastMovePlayedAt:    sql.NullTime{Time: playedAt, Valid: true},
+		StartedAt:           sql.NullTime{Time: playedAt, Valid: true},
+		EndedAt:             sql.NullTime{Time: playedAt, Valid: true}}
+
+	// The game set up from a custom position is replayed from the position in the FEN tag
+	if fen := p.Tag(game.PGNFENTag); fen != "" && fen != game.StartingFEN {
+		g.StartingFen = sql.NullString{String: fen, Valid: true}
+	}
+	classifyGameOpening(&g, gameModel)
+
+	if whitePlayer != nil {
+		g.WhitePlayerId = sql.NullInt64{Int64: whitePlayer.Id, Valid: true}
+	}
+	if blackPlayer != nil {
+		g.BlackPlayerId = sql.NullInt64{Int64: blackPlayer.Id, Valid: true}
+	}
+
+	// The result is stored even when the winner is not a registered player
+	g.Result = sql.NullString{String: p.Result, Valid: true}
+	if p.Result == game.WhiteWinsResult {
+		g.WinnerId = g.WhitePlayerId
+	} else if p.Result == game.BlackWinsResult {
+		g.WinnerId = g.BlackPlayerId
+	}
+
+	return g
 }
 
 // The elo after the match (R'a) is calculated using following formula:
@@ -646,13 +1077,248 @@ func getPlayerAndGame(c *gin.Context) (*repository.Player, *repository.Game, err
 	return player, g, nil, http.StatusOK
 }
 
+// recordGameMove saves the move played by the player, updates the game with the position after it and ends the game
+// if the move has decided its outcome or if the draw has been agreed
+func recordGameMove(g *repository.Game, player *repository.Player, gameModel *game.Game, move string,
+	outcome game.Outcome, isDraw bool) error {
+	endReason := GameEndAgreement
+	if outcome != game.NoOutcome {
+		isDraw = outcome.IsDraw()
+		endReason = string(outcome)
+	}
+
+	err := repository.CreateGameMove(g.Id, player.Id, move)
+	if err != nil {
+		return err
+	}
+
+	// The draw offers do not change the position, which is stored once for each move played
+	if !game.IsDrawMove(move) {
+		err = repository.CreateGamePosition(g.Id, len(gameModel.Moves), gameModel.Hash())
+		if err != nil {
+			return err
+		}
+	}
+
+	setGamePosition(g, gameModel)
+	classifyGameOpening(g, gameModel)
+	// Playing the move instead of responding to the takeback request declines it
+	g.TakebackPlayerId = sql.NullInt64{}
+	g.LastMovePlayedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	player.LastPlayedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	if outcome != game.NoOutcome || isDraw {
+		otherPlayerId := g.WhitePlayerId
+		if g.WhitePlayerId.Int64 == player.Id {
+			otherPlayerId = g.BlackPlayerId
+		}
+
+		otherPlayer, e := repository.FindPlayerById(otherPlayerId.Int64)
+		if e != nil {
+			return e
+		}
+
+		e = UpdateEndGameState(g, player, otherPlayer, isDraw, endReason)
+		if e != nil {
+			return e
+		}
+	} else {
+		err = repository.UpdatePlayer(player)
+		if err != nil {
+			return err
+		}
+		err = repository.UpdateGame(g)
+		if err != nil {
+			return err
+		}
+	}
+
+	SendEvent(GameMoveEvent, g.Id, player.Id, move)
+
+	if g.WhitePlayerId.Int64 == player.Id {
+		SendEvent(GameWhitePlayerMoveEvent, g.Id, player.Id, move)
+	} else {
+		SendEvent(GameBlackPlayerMoveEvent, g.Id, player.Id, move)
+	}
+
+	return nil
+}
+
+// setGamePosition sets the tiles and the FEN of the current position of the game, together with the pocket and the
+// checks given in the variants which have them
+func setGamePosition(g *repository.Game, gameModel *game.Game) {
+	position := gameModel.Position()
+	g.Tiles = gameModel.GetTiles()
+	g.Fen = sql.NullString{String: gameModel.FEN(), Valid: true}
+	g.Pocket = position.Pocket
+	g.WhiteChecks, g.BlackChecks = int32(position.Checks[0]), int32(position.Checks[1])
+}
+
+// classifyGameOpening sets the ECO code and name of the opening played in the standard game from the starting
+// position, which can change only during the first moves of the game covered by the ECO table
+func classifyGameOpening(g *repository.Game, gameModel *game.Game) {
+	if g.Variant != game.StandardVariant || g.StartingFen.Valid || len(gameModel.Moves) > eco.MaxPly() {
+		return
+	}
+
+	opening, err := eco.Classify(gameModel)
+	if err != nil {
+		log.Printf("Error while classifying opening of game with ID %d: %s", g.Id, err.Error())
+		return
+	}
+	if opening != nil {
+		g.Eco = sql.NullString{String: opening.Code, Valid: true}
+		g.Opening = sql.NullString{String: opening.Name, Valid: true}
+	}
+}
+
+func replayGameModel(g *repository.Game) (*game.Game, error) {
+	moves, err := queryGameMovesList(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReplayGameMoves(g, moves)
+}
+
+// ReplayGameMoves godoc
+// Creates the game by replaying the moves from its starting position, which is either the custom position or the
+// starting position of the variant.
+func ReplayGameMoves(g *repository.Game, moves []string) (*game.Game, error) {
+	if g.StartingFen.Valid {
+		return game.ReplayVariantGameFromFEN(g.Variant, g.StartingFen.String, moves)
+	}
+	return game.ReplayVariantGame(g.Variant, int(g.StartingPositionId), moves)
+}
+
+// parseCustomStartingPosition returns the custom starting position of the created game in Forsyth-Edwards Notation,
+// which is set either by FEN or by the tiles and the player on turn, or null if the game starts from the starting
+// position of the variant
+func parseCustomStartingPosition(gc *model.GameCreate, variant string) (sql.NullString, error) {
+	if gc.Fen == "" && gc.Tiles == "" {
+		return sql.NullString{}, nil
+	}
+	if gc.Fen != "" && gc.Tiles != "" {
+		return sql.NullString{}, errors.New("Starting position must be set either by FEN or by tiles")
+	}
+
+	var p *game.Position
+	var err error
+	if gc.Fen != "" {
+		p, err = game.ParseFEN(gc.Fen)
+	} else {
+		p, err = game.MakePositionFromTiles(gc.Tiles, !gc.BlackOnTurn)
+	}
+	if err == nil {
+		err = game.SetUpVariantPosition(p, variant)
+	}
+	if err != nil {
+		return sql.NullString{}, errors.New(fmt.Sprintf("Invalid starting position: %s", err.Error()))
+	}
+
+	return sql.NullString{String: p.FEN(), Valid: true}, nil
+}
+
+func queryGameMovesList(g *repository.Game) ([]string, error) {
+	gameMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, g.Id), 1, 10000, "createdAt")
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make([]string, 0)
+	for _, m := range *gameMoves {
+		moves = append(moves, m.Move)
+	}
+
+	return moves, nil
+}
+
+// addGameFormattedMoves converts all moves of the game from its starting position to the format, where the draw
+// offers and claims are kept as they are
+func addGameFormattedMoves(gameId int64, format string, formattedMoves map[int64]string) error {
+	g, err := repository.FindGameById(gameId)
+	if err != nil {
+		return err
+	}
+
+	gameMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, gameId), 1, 10000, "createdAt")
+	if err != nil {
+		return err
+	}
+
+	startingGame, err := ReplayGameMoves(g, []string{})
+	if err != nil {
+		return err
+	}
+
+	moves := make([]string, 0)
+	for _, m := range *gameMoves {
+		moves = append(moves, m.Move)
+	}
+
+	if format == game.UCIMoveFormat {
+		converted, e := game.MakeUCIMoves(startingGame.FEN(), moves)
+		if e != nil {
+			return e
+		}
+		for i, m := range *gameMoves {
+			formattedMoves[m.Id] = converted[i]
+		}
+		return nil
+	}
+
+	// The SAN moves are returned only for the moves played on board
+	converted, err := startingGame.Position().SANMoves(moves)
+	if err != nil {
+		return err
+	}
+	i := 0
+	for _, m := range *gameMoves {
+		formattedMoves[m.Id] = m.Move
+		if !game.IsDrawMove(m.Move) {
+			formattedMoves[m.Id] = converted[i]
+			i++
+		}
+	}
+
+	return nil
+}
+
+func gameResult(g *repository.Game) string {
+	if !g.EndedAt.Valid || !g.Result.Valid {
+		return game.UnknownResult
+	}
+	return g.Result.String
+}
+
+// endGameResult returns the result of the game ended by the win of the winner or by the draw
+func endGameResult(g *repository.Game, winner *repository.Player, isDraw bool) string {
+	if isDraw {
+		return game.DrawResult
+	}
+	if winner.Id == g.WhitePlayerId.Int64 {
+		return game.WhiteWinsResult
+	}
+	return game.BlackWinsResult
+}
+
+func pgnTagValue(value string) string {
+	if value == "" {
+		return "?"
+	}
+	return value
+}
+
 func makeGameDTO(g *repository.Game) model.Game {
 	return model.Game{Id: g.Id, Name: g.Name, TurnDurationSeconds: g.TurnDurationSeconds.Int32,
 		Public: !g.PasswordHash.Valid, WhitePlayerId: g.WhitePlayerId.Int64,
 		WhitePlayerUsername: g.WhitePlayerUsername.String, BlackPlayerId: g.BlackPlayerId.Int64,
-		BlackPlayerUsername: g.BlackPlayerUsername.String, WinnerId: g.WinnerId.Int64, CreatorId: g.CreatorId.Int64,
-		InProgress: g.InProgress, Tiles: g.Tiles, LastMovePlayedAt: g.FormatLastMovePlayedAt(),
-		StartedAt: g.FormatStartedAt(), EndedAt: g.FormatEndedAt(), CreatedAt: g.FormatCreatedAt()}
+		BlackPlayerUsername: g.BlackPlayerUsername.String, WinnerId: g.WinnerId.Int64, Result: g.Result.String,
+		CreatorId: g.CreatorId.Int64, InProgress: g.InProgress, Tiles: g.Tiles, Fen: g.Fen.String, Variant: g.Variant,
+		StartingPositionId: g.StartingPositionId, StartingFen: g.StartingFen.String, HintsEnabled: g.HintsEnabled,
+		Eco: g.Eco.String, Opening: g.Opening.String, TakebacksEnabled: g.TakebacksEnabled, TakebackPlayerId: g.TakebackPlayerId.Int64,
+		Pocket: g.Pocket, WhiteChecks: g.WhiteChecks, BlackChecks: g.BlackChecks,
+		LastMovePlayedAt: g.FormatLastMovePlayedAt(), StartedAt: g.FormatStartedAt(), EndedAt: g.FormatEndedAt(),
+		CreatedAt: g.FormatCreatedAt()}
 }
 
 func makeGameMoveDTO(gm *repository.GameMove) model.GameMove {
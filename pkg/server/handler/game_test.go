@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"testing"
+)
+
+func TestImportDecisiveGameOfUnregisteredPlayers(t *testing.T) {
+	pgnGames, err := game.ParsePGN(`[Event "Casual game"]
+[White "alice"]
+[Black "bob"]
+[Result "0-1"]
+
+1. f3 e5 2. g4 Qh4# 0-1
+`)
+	utils.AssertTestCondition(t, nil, err, "PGN should be parsed without error")
+
+	gameModel, _, _, err := pgnGames[0].Replay()
+	utils.AssertTestCondition(t, nil, err, "PGN should be replayed without error")
+
+	g := makeImportedGame(&repository.Player{Id: 1}, &pgnGames[0], gameModel, nil, nil)
+	utils.AssertTestCondition(t, false, g.WinnerId.Valid, "Unregistered winner should not be set")
+	utils.AssertTestCondition(t, game.BlackWinsResult, g.Result.String, "Result should be stored")
+	utils.AssertTestCondition(t, game.BlackWinsResult, gameResult(&g), "Result should be exported")
+}
+
+func TestImportedGameOpening(t *testing.T) {
+	pgnGames, err := game.ParsePGN(`[Event "Casual game"]
+[Result "1/2-1/2"]
+
+1. e4 e5 2. Nf3 Nc6 3. Bb5 Nf6 1/2-1/2
+`)
+	utils.AssertTestCondition(t, nil, err, "PGN should be parsed without error")
+
+	gameModel, _, _, err := pgnGames[0].Replay()
+	utils.AssertTestCondition(t, nil, err, "PGN should be replayed without error")
+
+	g := makeImportedGame(&repository.Player{Id: 1}, &pgnGames[0], gameModel, nil, nil)
+	utils.AssertTestCondition(t, "C65", g.Eco.String, "Opening of imported game should be classified")
+}
+
+func TestImportedSetUpGameOpening(t *testing.T) {
+	pgnGames, err := game.ParsePGN(`[Event "Casual game"]
+[Result "1/2-1/2"]
+[SetUp "1"]
+[FEN "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2"]
+
+2. Nf3 Nc6 3. Bb5 Nf6 1/2-1/2
+`)
+	utils.AssertTestCondition(t, nil, err, "PGN should be parsed without error")
+
+	gameModel, _, _, err := pgnGames[0].Replay()
+	utils.AssertTestCondition(t, nil, err, "PGN should be replayed without error")
+
+	g := makeImportedGame(&repository.Player{Id: 1}, &pgnGames[0], gameModel, nil, nil)
+	utils.AssertTestCondition(t, true, g.StartingFen.Valid, "Starting position of imported game should be stored")
+	utils.AssertTestCondition(t, false, g.Eco.Valid, "Opening of game from custom position should not be classified")
+}
@@ -213,5 +213,6 @@ func DeletePlayer(c *gin.Context) {
 
 func makePlayerDTO(p *repository.Player) model.Player {
 	return model.Player{Id: p.Id, Username: p.Username, Wins: p.Wins, Losses: p.Losses, Draws: p.Draws, Rate: p.Rate,
-		Elo: p.Elo, IsPlaying: p.IsPlaying, LastPlayedAt: p.FormatLastPlayedAt(), CreatedAt: p.FormatCreatedAt()}
+		Elo: p.Elo, PuzzleElo: p.PuzzleElo, IsPlaying: p.IsPlaying, IsBot: p.IsBot,
+		LastPlayedAt: p.FormatLastPlayedAt(), CreatedAt: p.FormatCreatedAt()}
 }
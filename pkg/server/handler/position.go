@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"net/http"
+)
+
+// ListPositionGames godoc
+// @Summary List games by position
+// @Description Query and list games which have passed through the position in URL encoded FEN, where the positions
+// @Description are equal if they have the same figures, player on turn, castling rights and en passant tile
+// @Tags positions
+// @Produce json
+// @Param fen path string true "Position in FEN"
+// @Param page query int false "Page"
+// @Param size query int false "Size"
+// @Param sort query string false "Sort"
+// @Param filter query string false "Filter"
+// @Success 200 {object} model.GameListResponse "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/positions/{fen}/games [get]
+func ListPositionGames(c *gin.Context) {
+	_, err := GetAuthPlayer(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	hash, err := game.HashFEN(c.Param("fen"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: fmt.Sprintf("Invalid position: %s", err.Error())})
+		return
+	}
+
+	page, size, sort, filter := ParseQueryParams(c)
+
+	games, err := repository.QueryGamesByPosition(hash, filter, page, size, sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	totalCount, err := repository.CountGamesByPosition(hash, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	gamesDTO := make([]model.Game, 0)
+	for _, g := range *games {
+		gamesDTO = append(gamesDTO, makeGameDTO(&g))
+	}
+
+	c.JSON(http.StatusOK, model.ListResponse[model.Game]{
+		Items:       gamesDTO,
+		ResultCount: len(gamesDTO),
+		TotalCount:  totalCount,
+	})
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetNextPuzzle godoc
+// @Summary Get next puzzle
+// @Description Get the random puzzle not yet solved or failed by the player with the rating closest to the puzzle
+// @Description rating of the player
+// @Tags puzzles
+// @Produce json
+// @Success 200 {object} model.Puzzle "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/puzzles/next [get]
+func GetNextPuzzle(c *gin.Context) {
+	player, err := GetAuthPlayer(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	p, err := repository.FindNextPuzzle(player.Id, player.PuzzleElo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	puzzleDTO, err := makePuzzleDTO(p)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, puzzleDTO)
+}
+
+// FindOnePuzzle godoc
+// @Summary Find one puzzle
+// @Description Find one puzzle without its solution
+// @Tags puzzles
+// @Produce json
+// @Param id path int true "Puzzle ID"
+// @Success 200 {object} model.Puzzle "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/puzzles/{id} [get]
+func FindOnePuzzle(c *gin.Context) {
+	_, p, err, code := getPlayerAndPuzzle(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	puzzleDTO, err := makePuzzleDTO(p)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, puzzleDTO)
+}
+
+// AttemptPuzzle godoc
+// @Summary Attempt to solve puzzle
+// @Description Check the moves of the player in SAN or UCI format played from the starting position of the puzzle,
+// @Description where each move is followed by the reply of the opponent from the solution. The puzzle is finished
+// @Description when it is solved or when the move differs from the solution, and only the first finished attempt of
+// @Description the player updates the puzzle ratings of both the player and the puzzle.
+// @Tags puzzles
+// @Accept json
+// @Produce json
+// @Param id path int true "Puzzle ID"
+// @Param attempt body model.PuzzleAttempt true "Puzzle attempt"
+// @Success 200 {object} model.PuzzleAttemptResult "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/puzzles/{id}/attempt [post]
+func AttemptPuzzle(c *gin.Context) {
+	player, p, err, code := getPlayerAndPuzzle(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	pa, err := utils.ParseJson[model.PuzzleAttempt](c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+	if len(pa.Moves) == 0 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: "At least one move is required"})
+		return
+	}
+
+	solution := strings.Fields(p.Solution)
+	progress, err := game.CheckPuzzleMoves(p.Fen, solution, pa.Moves)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	startingPosition, err := game.ParseFEN(p.Fen)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	result := model.PuzzleAttemptResult{Correct: !progress.IsFailed, Solved: progress.IsSolved,
+		PuzzleRating: p.Rating, PlayerRating: player.PuzzleElo}
+
+	if !progress.IsSolved && !progress.IsFailed {
+		sanMoves, e := startingPosition.SANMoves(solution[:2*len(pa.Moves)])
+		if e != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
+			return
+		}
+
+		replyGame, e := game.MakeGameFromFEN(progress.Fen, []string{})
+		if e != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: e.Error()})
+			return
+		}
+
+		result.Reply, result.ReplySan = progress.Reply, sanMoves[len(sanMoves)-1]
+		result.Fen, result.Tiles = progress.Fen, replyGame.GetTiles()
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	result.Solution, err = startingPosition.SANMoves(solution)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	attempt, err := repository.FindPuzzleAttempt(p.Id, player.Id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if attempt == nil {
+		playerElo := player.PuzzleElo
+		err = updatePuzzleRatings(player, p, progress.IsSolved)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+			return
+		}
+		result.PuzzleRating, result.PlayerRating = p.Rating, player.PuzzleElo
+		result.RatingChange = player.PuzzleElo - playerElo
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ImportPuzzles godoc
+// @Summary Import puzzles from Lichess CSV
+// @Description Import puzzles in the CSV format of the Lichess puzzle database, where the puzzles which have already
+// @Description been imported are skipped, only for admins
+// @Tags puzzles
+// @Accept json
+// @Produce json
+// @Param puzzle body model.PuzzleImport true "Import puzzles"
+// @Success 200 {object} model.PuzzleImportResult "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/puzzles/import [post]
+func ImportPuzzles(c *gin.Context) {
+	player, err := GetAuthPlayer(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if !IsAdminPlayer(player) {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Only admins can import puzzles"})
+		return
+	}
+
+	pi, err := utils.ParseJson[model.PuzzleImport](c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	// All puzzles are validated before any of them is imported
+	lichessPuzzles, err := game.ParseLichessPuzzles(pi.Csv)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	puzzles := make([]repository.Puzzle, 0)
+	for _, lp := range lichessPuzzles {
+		puzzles = append(puzzles, repository.Puzzle{LichessId: sql.NullString{String: lp.Id, Valid: lp.Id != ""},
+			Fen: lp.Fen, Solution: strings.Join(lp.Solution, " "), Rating: int32(lp.Rating),
+			Themes: strings.Join(lp.Themes, " ")})
+	}
+
+	imported, err := repository.CreatePuzzles(puzzles)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.PuzzleImportResult{Parsed: len(puzzles), Imported: imported})
+}
+
+// updatePuzzleRatings records the finished attempt of the player and updates the ratings of the player and the puzzle
+// as if they played the game, which the player wins by solving the puzzle
+func updatePuzzleRatings(player *repository.Player, p *repository.Puzzle, isSolved bool) error {
+	err := repository.CreatePuzzleAttempt(p.Id, player.Id, isSolved)
+	if err != nil {
+		return err
+	}
+
+	playerElo, puzzleElo := player.PuzzleElo, p.Rating
+	player.PuzzleElo = calculateElo(playerElo, puzzleElo, isSolved, false)
+	p.Rating = calculateElo(puzzleElo, playerElo, !isSolved, false)
+	p.Plays = p.Plays + 1
+
+	err = repository.UpdatePlayer(player)
+	if err != nil {
+		return err
+	}
+
+	return repository.UpdatePuzzle(p)
+}
+
+func getPlayerAndPuzzle(c *gin.Context) (*repository.Player, *repository.Puzzle, error, int) {
+	player, err := GetAuthPlayer(c)
+	if err != nil {
+		return nil, nil, err, http.StatusUnauthorized
+	}
+
+	idParam, _ := c.Params.Get("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return nil, nil, err, http.StatusBadRequest
+	}
+
+	p, err := repository.FindPuzzleById(int64(id))
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+
+	return player, p, nil, http.StatusOK
+}
+
+func makePuzzleDTO(p *repository.Puzzle) (model.Puzzle, error) {
+	g, err := game.MakeGameFromFEN(p.Fen, []string{})
+	if err != nil {
+		return model.Puzzle{}, errors.New(fmt.Sprintf("Invalid puzzle position: %s", err.Error()))
+	}
+
+	return model.Puzzle{Id: p.Id, LichessId: p.LichessId.String, Fen: p.Fen, Tiles: g.GetTiles(),
+		IsWhiteTurn: g.Position().IsWhiteTurn, Rating: p.Rating, Themes: strings.Fields(p.Themes), Plays: p.Plays,
+		CreatedAt: p.FormatCreatedAt()}, nil
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/game"
+	"github.com/lmatosevic/chess-cli/pkg/model"
+	"github.com/lmatosevic/chess-cli/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// RequestGameTakeback godoc
+// @Summary Request takeback
+// @Description Request to take back the last move of the player, together with the reply of the opponent if it was
+// @Description already played. The opponent must accept the request, while the computer player accepts it immediately.
+// @Tags games
+// @Produce json
+// @Param id path int true "Game ID"
+// @Success 200 {object} model.Game "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/takeback [post]
+func RequestGameTakeback(c *gin.Context) {
+	player, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if g.WhitePlayerId.Int64 != player.Id && g.BlackPlayerId.Int64 != player.Id {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Forbidden access to not joined game"})
+		return
+	}
+
+	if !g.InProgress {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false,
+			Error: "Cannot take back a move in not started game"})
+		return
+	}
+
+	if !g.TakebacksEnabled {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Takebacks are disabled in this game"})
+		return
+	}
+
+	if g.TakebackPlayerId.Valid {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: "There is already a takeback request waiting for response"})
+		return
+	}
+
+	_, _, err = findTakebackMove(g, player.Id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	opponentId := g.WhitePlayerId.Int64
+	if opponentId == player.Id {
+		opponentId = g.BlackPlayerId.Int64
+	}
+	opponent, err := repository.FindPlayerById(opponentId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if opponent.IsBot {
+		// The move which the computer player is searching for would be played in the position taken back
+		if _, isSearching := botGames.Load(g.Id); isSearching {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+				Error: "Computer player is thinking, request the takeback after its move"})
+			return
+		}
+
+		err = takeBackMoves(g, player.Id, opponent.Id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, makeGameDTO(g))
+		return
+	}
+
+	g.TakebackPlayerId = sql.NullInt64{Int64: player.Id, Valid: true}
+	err = repository.UpdateGame(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	SendEvent(GameTakebackEvent, g.Id, player.Id, TakebackRequest)
+
+	c.JSON(http.StatusOK, makeGameDTO(g))
+}
+
+// RespondGameTakeback godoc
+// @Summary Respond to takeback request
+// @Description Accept or decline the takeback request of the opponent
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param id path int true "Game ID"
+// @Param takeback body model.GameTakebackResponse true "Takeback response"
+// @Success 200 {object} model.Game "Ok"
+// @Failure 400 {object} model.ErrorResponse "Bad Request"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /v1/games/{id}/takeback/respond [post]
+func RespondGameTakeback(c *gin.Context) {
+	player, g, err, code := getPlayerAndGame(c)
+	if err != nil {
+		c.JSON(code, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if g.WhitePlayerId.Int64 != player.Id && g.BlackPlayerId.Int64 != player.Id {
+		c.JSON(http.StatusForbidden, model.ErrorResponse{Success: false, Error: "Forbidden access to not joined game"})
+		return
+	}
+
+	if !g.InProgress || !g.TakebackPlayerId.Valid || g.TakebackPlayerId.Int64 == player.Id {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false,
+			Error: "There is no takeback request from opponent to respond to"})
+		return
+	}
+
+	gtr, err := utils.ParseJson[model.GameTakebackResponse](c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if gtr.Accept {
+		err = takeBackMoves(g, g.TakebackPlayerId.Int64, player.Id)
+	} else {
+		g.TakebackPlayerId = sql.NullInt64{}
+		err = repository.UpdateGame(g)
+		if err == nil {
+			SendEvent(GameTakebackEvent, g.Id, player.Id, TakebackDecline)
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, makeGameDTO(g))
+}
+
+// findTakebackMove returns the moves played in the game and the index of the last move played by the player, which is
+// taken back together with all moves and draw offers played after it
+func findTakebackMove(g *repository.Game, playerId int64) ([]repository.GameMove, int, error) {
+	gameMoves, err := repository.QueryGameMoves(fmt.Sprintf(`gameId=%d`, g.Id), 1, 10000, "createdAt")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := len(*gameMoves) - 1; i >= 0; i-- {
+		m := (*gameMoves)[i]
+		if m.PlayerId.Int64 == playerId && !game.IsDrawMove(m.Move) {
+			return *gameMoves, i, nil
+		}
+	}
+
+	return nil, 0, errors.New("There is no move to take back")
+}
+
+// takeBackMoves deletes the last move of the requesting player with all moves played after it and restores the game
+// to the position before it, after which the requesting player is on turn again
+func takeBackMoves(g *repository.Game, requesterId int64, responderId int64) error {
+	gameMoves, index, err := findTakebackMove(g, requesterId)
+	if err != nil {
+		return err
+	}
+
+	moves := make([]string, 0)
+	isMovePlayed := false
+	for _, m := range gameMoves[:index] {
+		moves = append(moves, m.Move)
+		isMovePlayed = isMovePlayed || !game.IsDrawMove(m.Move)
+	}
+
+	gameModel, err := ReplayGameMoves(g, moves)
+	if err != nil {
+		return err
+	}
+
+	setGamePosition(g, gameModel)
+	g.Eco, g.Opening = sql.NullString{}, sql.NullString{}
+	classifyGameOpening(g, gameModel)
+	g.TakebackPlayerId = sql.NullInt64{}
+
+	// The turn timer is restarted, and the game without any moves played is timed from its start as a new game
+	now := time.Now().UTC()
+	if isMovePlayed {
+		g.LastMovePlayedAt = sql.NullTime{Time: now, Valid: true}
+	} else {
+		g.LastMovePlayedAt = sql.NullTime{}
+		g.StartedAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	err = repository.TakeBackGameMoves(g, gameMoves[index].Id, len(gameModel.Moves))
+	if err != nil {
+		return err
+	}
+
+	SendEvent(GameTakebackEvent, g.Id, responderId, TakebackAccept)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/server/handler"
+	"log"
+)
+
+// The number of ended games analyzed by a single run of the job, since the search of every position takes time
+const analyzedGamesLimit = 5
+
+// AnalyzeEndedGames analyzes the moves of the most recently ended games which have not been analyzed yet
+func AnalyzeEndedGames() {
+	games, err := repository.FindNotAnalyzedGames(analyzedGamesLimit)
+	if err != nil {
+		log.Printf("Error while querying games which have not been analyzed: %s", err.Error())
+		return
+	}
+
+	for _, game := range *games {
+		err = handler.AnalyzeEndedGame(&game)
+		if err != nil {
+			log.Printf("Error while analyzing game with ID: %d", game.Id)
+			log.Println(err)
+		}
+	}
+
+	if len(*games) > 0 {
+		log.Printf("Analyzed %d ended games", len(*games))
+	}
+}
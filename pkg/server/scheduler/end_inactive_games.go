@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	chess "github.com/lmatosevic/chess-cli/pkg/game"
 	"github.com/lmatosevic/chess-cli/pkg/server/handler"
 	"log"
 )
@@ -15,13 +16,17 @@ func EndInactiveGames() {
 	}
 
 	for _, game := range *inactiveGames {
+		moves := &[]repository.GameMove{}
 		if game.LastMovePlayedAt.Valid {
-			moves, e := repository.QueryGameMoves(fmt.Sprintf("gameId=%d", game.Id), 1, 10000, "")
-			if e != nil {
-				log.Printf("Error while querying game moves: %s", e.Error())
+			moves, err = repository.QueryGameMoves(fmt.Sprintf("gameId=%d", game.Id), 1, 10000, "createdAt")
+			if err != nil {
+				log.Printf("Error while querying game moves: %s", err.Error())
 				continue
 			}
+		}
 
+		// The game in which no moves have been played is deleted instead of being ended
+		if len(*moves) > 0 {
 			winner, e := repository.FindPlayerById((*moves)[len(*moves)-1].PlayerId.Int64)
 			if e != nil {
 				log.Printf("Error while querying player: %s", e.Error())
@@ -39,7 +44,21 @@ func EndInactiveGames() {
 				continue
 			}
 
-			err = handler.UpdateEndGameState(&game, winner, loser, false)
+			// The player who ran out of time gets a draw if the opponent cannot checkmate them by any legal moves, which
+			// is checked only in standard chess, since the material does not decide the outcome of the variants
+			isDraw := false
+			gameMoves := make([]string, 0)
+			for _, m := range *moves {
+				gameMoves = append(gameMoves, m.Move)
+			}
+			gameModel, e := handler.ReplayGameMoves(&game, gameMoves)
+			if e != nil {
+				log.Printf("Error while replaying game moves: %s", e.Error())
+			} else if gameModel.Position().Variant == nil {
+				isDraw = !chess.HasMatingMaterial(&gameModel.Board, game.WhitePlayerId.Int64 == winner.Id)
+			}
+
+			err = handler.UpdateEndGameState(&game, winner, loser, isDraw, handler.GameEndTimeout)
 		} else {
 			err = repository.DeleteGame(game.Id)
 		}
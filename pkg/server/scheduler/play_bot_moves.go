@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"github.com/lmatosevic/chess-cli/pkg/database/repository"
+	"github.com/lmatosevic/chess-cli/pkg/server/handler"
+	"log"
+)
+
+// PlayBotMoves resumes the games in which the computer player is on turn, but its move has not been searched, e.g.
+// after the server restart
+func PlayBotMoves() {
+	botGames, err := repository.FindBotGames()
+	if err != nil {
+		log.Printf("Error while querying games with computer players: %s", err.Error())
+		return
+	}
+
+	for _, game := range *botGames {
+		handler.PlayBotMove(game.Id)
+	}
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"github.com/go-co-op/gocron"
+	"github.com/lmatosevic/chess-cli/configs"
 	"log"
 	"time"
 )
@@ -14,5 +15,18 @@ func Start() {
 		log.Fatalf("Error while starting the scheduled job: %s", err.Error())
 	}
 
+	_, err = s.Every(10).Seconds().Do(PlayBotMoves)
+	if err != nil {
+		log.Fatalf("Error while starting the scheduled job: %s", err.Error())
+	}
+
+	// The analysis of the ended games is optional, and the next run waits for the previous one to finish
+	if configs.GetConfig().Analysis.Enabled {
+		_, err = s.Every(30).Seconds().SingletonMode().Do(AnalyzeEndedGames)
+		if err != nil {
+			log.Fatalf("Error while starting the scheduled job: %s", err.Error())
+		}
+	}
+
 	s.StartAsync()
 }
@@ -40,6 +40,9 @@ func Run() {
 
 	r := gin.Default()
 
+	// The slashes in the URL encoded path parameters (e.g. FEN) are not treated as path separators
+	r.UseRawPath = true
+
 	// Enable CORS
 	r.Use(cors.Default())
 
@@ -66,10 +69,30 @@ func Run() {
 			games.GET("/", handler.ListGames)
 			games.GET("/:id", handler.FindOneGame)
 			games.POST("/create", handler.CreateGame)
+			games.POST("/import", handler.ImportGames)
 			games.POST("/:id/join", handler.JoinGame)
 			games.POST("/:id/quit", handler.QuitGame)
 			games.GET("/:id/moves", handler.ListGameMoves)
 			games.POST("/:id/move", handler.MakeGameMove)
+			games.GET("/:id/legal-moves", handler.ListGameLegalMoves)
+			games.GET("/:id/pgn", handler.ExportGamePGN)
+			games.GET("/:id/analysis", handler.GetGameAnalysis)
+			games.PUT("/:id/hints", handler.UpdateGameHints)
+			games.POST("/:id/takeback", handler.RequestGameTakeback)
+			games.POST("/:id/takeback/respond", handler.RespondGameTakeback)
+		}
+
+		positions := v1.Group("/positions")
+		{
+			positions.GET("/:fen/games", handler.ListPositionGames)
+		}
+
+		puzzles := v1.Group("/puzzles")
+		{
+			puzzles.GET("/next", handler.GetNextPuzzle)
+			puzzles.GET("/:id", handler.FindOnePuzzle)
+			puzzles.POST("/import", handler.ImportPuzzles)
+			puzzles.POST("/:id/attempt", handler.AttemptPuzzle)
 		}
 
 		auth := v1.Group("/auth")